@@ -0,0 +1,79 @@
+package dataframe
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestGroupByCategorical(t *testing.T) {
+	sizes := series.NewCategorical([]string{"large", "small", "large", "medium"}, []string{"small", "medium", "large"}, true, "size")
+	df := New(sizes, series.New([]int{1, 2, 3, 4}, series.Int, "n"))
+	gps := df.GroupBy("size")
+	if gps.Err != nil {
+		t.Fatal(gps.Err)
+	}
+	groups := gps.GetGroups()
+	var keys []string
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if want := []string{"large", "medium", "small"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("keys = %v, want %v", keys, want)
+	}
+	large := groups["large"]
+	checkRecords(t, large, [][]string{{"size", "n"}, {"large", "1"}, {"large", "3"}})
+	if col := large.Col("size"); col.Type() != series.Categorical || !col.Ordered() ||
+		!reflect.DeepEqual(col.Categories(), []string{"small", "medium", "large"}) {
+		t.Errorf("group column lost its categories: %v %v %v", col.Type(), col.Ordered(), col.Categories())
+	}
+
+	agg := gps.Aggregation([]AggregationType{Aggregation_SUM}, []string{"n"}).Arrange(Sort("size"))
+	checkRecords(t, agg, [][]string{
+		{"n_SUM", "size"},
+		{"2.000000", "small"},
+		{"4.000000", "medium"},
+		{"4.000000", "large"},
+	})
+}
+
+func TestJoinCategorical(t *testing.T) {
+	left := New(
+		series.New([]string{"cn", "us", "jp", "cn"}, series.Categorical, "country"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "id"),
+	)
+	right := New(
+		series.New([]string{"us", "cn", "de"}, series.Categorical, "country"),
+		series.New([]string{"Washington", "Beijing", "Berlin"}, series.String, "capital"),
+	)
+	inner := left.InnerJoin(right, "country")
+	checkRecords(t, inner, [][]string{
+		{"country", "id", "capital"},
+		{"cn", "1", "Beijing"},
+		{"us", "2", "Washington"},
+		{"cn", "4", "Beijing"},
+	})
+	if got := inner.Col("country"); got.Type() != series.Categorical {
+		t.Errorf("key type = %v, want categorical", got.Type())
+	}
+	checkRecords(t, left.LeftJoin(right, "country"), [][]string{
+		{"country", "id", "capital"},
+		{"cn", "1", "Beijing"},
+		{"us", "2", "Washington"},
+		{"jp", "3", "NaN"},
+		{"cn", "4", "Beijing"},
+	})
+
+	// Categorical 与 String 键之间按值比较
+	mixed := New(
+		series.New([]string{"de"}, series.String, "country"),
+		series.New([]int{49}, series.Int, "code"),
+	)
+	checkRecords(t, right.InnerJoin(mixed, "country"), [][]string{
+		{"country", "capital", "code"},
+		{"de", "Berlin", "49"},
+	})
+}
@@ -0,0 +1,367 @@
+package dataframe
+
+import (
+	"io"
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// 本文件中以 E 结尾的函数和方法与对应的同名函数功能相同，但通过返回值报告错误，而不是只设置 Err 字段。
+// 返回的错误记录了失败的操作名称；如果操作的输入本身已经带有错误，返回的错误会包装该错误，
+// 可以使用 series.Operations 获取整条调用链中失败的操作。返回的 DataFrame 与对应函数的结果相同。
+
+// checked 将 ret 的 Err 字段转换为返回的错误，并在错误中记录操作名称 op。inputs 为操作输入的错误，
+// 任一输入带有错误时，返回的错误说明错误来自之前的操作。
+func checked(op string, ret DataFrame, inputs ...error) (DataFrame, error) {
+	return ret, checkedErr(op, ret.Err, inputs...)
+}
+
+// checkedErr 返回操作 op 的错误，规则与 checked 相同。
+func checkedErr(op string, err error, inputs ...error) error {
+	for _, in := range inputs {
+		if in != nil {
+			e := series.WrapError(op, in)
+			e.Msg = "input_has_errors"
+			return e
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*series.Error); ok && e.Op == op {
+		return err
+	}
+	return series.WrapError(op, err)
+}
+
+// checkedSeries 与 checked 相同，用于返回 Series 的操作。
+func checkedSeries(op string, ret series.Series, inputs ...error) (series.Series, error) {
+	return ret, checkedErr(op, ret.Err, inputs...)
+}
+
+// NewE 与 New 相同，但返回错误。
+func NewE(se ...series.Series) (DataFrame, error) {
+	return checked("New", New(se...))
+}
+
+// LoadStructsE 与 LoadStructs 相同，但返回错误。
+func LoadStructsE(i interface{}, options ...LoadOption) (DataFrame, error) {
+	return checked("LoadStructs", LoadStructs(i, options...))
+}
+
+// LoadRecordsE 与 LoadRecords 相同，但返回错误。
+func LoadRecordsE(records [][]string, options ...LoadOption) (DataFrame, error) {
+	return checked("LoadRecords", LoadRecords(records, options...))
+}
+
+// LoadMapsE 与 LoadMaps 相同，但返回错误。
+func LoadMapsE(maps []map[string]interface{}, options ...LoadOption) (DataFrame, error) {
+	return checked("LoadMaps", LoadMaps(maps, options...))
+}
+
+// LoadMatrixE 与 LoadMatrix 相同，但返回错误。
+func LoadMatrixE(mat Matrix) (DataFrame, error) {
+	return checked("LoadMatrix", LoadMatrix(mat))
+}
+
+// ReadCSVE 与 ReadCSV 相同，但返回错误。
+func ReadCSVE(r io.Reader, options ...LoadOption) (DataFrame, error) {
+	return checked("ReadCSV", ReadCSV(r, options...))
+}
+
+// ReadJSONE 与 ReadJSON 相同，但返回错误。
+func ReadJSONE(r io.Reader, options ...LoadOption) (DataFrame, error) {
+	return checked("ReadJSON", ReadJSON(r, options...))
+}
+
+// ReadCSVFileE 与 ReadCSVFile 相同，但返回错误。
+func ReadCSVFileE(path string, options ...LoadOption) (DataFrame, error) {
+	return checked("ReadCSVFile", ReadCSVFile(path, options...))
+}
+
+// ReadJSONFileE 与 ReadJSONFile 相同，但返回错误。
+func ReadJSONFileE(path string, options ...LoadOption) (DataFrame, error) {
+	return checked("ReadJSONFile", ReadJSONFile(path, options...))
+}
+
+// ReadJSONLinesE 与 ReadJSONLines 相同，但返回错误。
+func ReadJSONLinesE(r io.Reader, options ...LoadOption) (DataFrame, error) {
+	return checked("ReadJSONLines", ReadJSONLines(r, options...))
+}
+
+// JSONNormalizeE 与 JSONNormalize 相同，但返回错误。
+func JSONNormalizeE(r io.Reader, options ...LoadOption) (DataFrame, error) {
+	return checked("JSONNormalize", JSONNormalize(r, options...))
+}
+
+// ReadHTMLE 与 ReadHTML 相同，但返回第一个读取失败的表格的错误。
+func ReadHTMLE(r io.Reader, options ...LoadOption) ([]DataFrame, error) {
+	dfs := ReadHTML(r, options...)
+	for _, df := range dfs {
+		if df.Err != nil {
+			return dfs, checkedErr("ReadHTML", df.Err)
+		}
+	}
+	return dfs, nil
+}
+
+// CopyE 与 Copy 相同，但返回错误。
+func (df DataFrame) CopyE() (DataFrame, error) {
+	return checked("Copy", df.Copy(), df.Err)
+}
+
+// ColE 与 Col 相同，但返回错误。
+func (df DataFrame) ColE(colname string) (series.Series, error) {
+	return checkedSeries("Col", df.Col(colname), df.Err)
+}
+
+// SetE 与 Set 相同，但返回错误。
+func (df DataFrame) SetE(indexes series.Indexes, newvalues DataFrame) (DataFrame, error) {
+	return checked("Set", df.Set(indexes, newvalues), df.Err)
+}
+
+// SubsetE 与 Subset 相同，但返回错误。
+func (df DataFrame) SubsetE(indexes series.Indexes) (DataFrame, error) {
+	return checked("Subset", df.Subset(indexes), df.Err)
+}
+
+// SelectE 与 Select 相同，但返回错误。
+func (df DataFrame) SelectE(indexes SelectIndexes) (DataFrame, error) {
+	return checked("Select", df.Select(indexes), df.Err)
+}
+
+// DropE 与 Drop 相同，但返回错误。
+func (df DataFrame) DropE(indexes SelectIndexes) (DataFrame, error) {
+	return checked("Drop", df.Drop(indexes), df.Err)
+}
+
+// RenameE 与 Rename 相同，但返回错误。
+func (df DataFrame) RenameE(newname, oldname string) (DataFrame, error) {
+	return checked("Rename", df.Rename(newname, oldname), df.Err)
+}
+
+// CBindE 与 CBind 相同，但返回错误。
+func (df DataFrame) CBindE(dfb DataFrame) (DataFrame, error) {
+	return checked("CBind", df.CBind(dfb), df.Err, dfb.Err)
+}
+
+// RBindE 与 RBind 相同，但返回错误。
+func (df DataFrame) RBindE(dfb DataFrame) (DataFrame, error) {
+	return checked("RBind", df.RBind(dfb), df.Err, dfb.Err)
+}
+
+// ConcatE 与 Concat 相同，但返回错误。
+func (df DataFrame) ConcatE(dfb DataFrame) (DataFrame, error) {
+	return checked("Concat", df.Concat(dfb), df.Err, dfb.Err)
+}
+
+// MutateE 与 Mutate 相同，但返回错误。
+func (df DataFrame) MutateE(s series.Series) (DataFrame, error) {
+	return checked("Mutate", df.Mutate(s), df.Err, s.Err)
+}
+
+// FilterE 与 Filter 相同，但返回错误。
+func (df DataFrame) FilterE(filters ...F) (DataFrame, error) {
+	return checked("Filter", df.Filter(filters...), df.Err)
+}
+
+// FilterAggregationE 与 FilterAggregation 相同，但返回错误。
+func (df DataFrame) FilterAggregationE(agg Aggregation, filters ...F) (DataFrame, error) {
+	return checked("FilterAggregation", df.FilterAggregation(agg, filters...), df.Err)
+}
+
+// ArrangeE 与 Arrange 相同，但返回错误。
+func (df DataFrame) ArrangeE(order ...Order) (DataFrame, error) {
+	return checked("Arrange", df.Arrange(order...), df.Err)
+}
+
+// CapplyE 与 Capply 相同，但返回错误。
+func (df DataFrame) CapplyE(f func(series.Series) series.Series) (DataFrame, error) {
+	return checked("Capply", df.Capply(f), df.Err)
+}
+
+// RapplyE 与 Rapply 相同，但返回错误。
+func (df DataFrame) RapplyE(f func(series.Series) series.Series) (DataFrame, error) {
+	return checked("Rapply", df.Rapply(f), df.Err)
+}
+
+// InnerJoinE 与 InnerJoin 相同，但返回错误。
+func (df DataFrame) InnerJoinE(b DataFrame, keys ...string) (DataFrame, error) {
+	return checked("InnerJoin", df.InnerJoin(b, keys...), df.Err, b.Err)
+}
+
+// LeftJoinE 与 LeftJoin 相同，但返回错误。
+func (df DataFrame) LeftJoinE(b DataFrame, keys ...string) (DataFrame, error) {
+	return checked("LeftJoin", df.LeftJoin(b, keys...), df.Err, b.Err)
+}
+
+// RightJoinE 与 RightJoin 相同，但返回错误。
+func (df DataFrame) RightJoinE(b DataFrame, keys ...string) (DataFrame, error) {
+	return checked("RightJoin", df.RightJoin(b, keys...), df.Err, b.Err)
+}
+
+// OuterJoinE 与 OuterJoin 相同，但返回错误。
+func (df DataFrame) OuterJoinE(b DataFrame, keys ...string) (DataFrame, error) {
+	return checked("OuterJoin", df.OuterJoin(b, keys...), df.Err, b.Err)
+}
+
+// CrossJoinE 与 CrossJoin 相同，但返回错误。
+func (df DataFrame) CrossJoinE(b DataFrame) (DataFrame, error) {
+	return checked("CrossJoin", df.CrossJoin(b), df.Err, b.Err)
+}
+
+// DescribeE 与 Describe 相同，但返回错误。
+func (df DataFrame) DescribeE() (DataFrame, error) {
+	return checked("Describe", df.Describe(), df.Err)
+}
+
+// CumSumE 与 CumSum 相同，但返回错误。
+func (df DataFrame) CumSumE(colnames ...string) (DataFrame, error) {
+	return checked("CumSum", df.CumSum(colnames...), df.Err)
+}
+
+// CumProdE 与 CumProd 相同，但返回错误。
+func (df DataFrame) CumProdE(colnames ...string) (DataFrame, error) {
+	return checked("CumProd", df.CumProd(colnames...), df.Err)
+}
+
+// CumMaxE 与 CumMax 相同，但返回错误。
+func (df DataFrame) CumMaxE(colnames ...string) (DataFrame, error) {
+	return checked("CumMax", df.CumMax(colnames...), df.Err)
+}
+
+// CumMinE 与 CumMin 相同，但返回错误。
+func (df DataFrame) CumMinE(colnames ...string) (DataFrame, error) {
+	return checked("CumMin", df.CumMin(colnames...), df.Err)
+}
+
+// DiffE 与 Diff 相同，但返回错误。
+func (df DataFrame) DiffE(periods int, colnames ...string) (DataFrame, error) {
+	return checked("Diff", df.Diff(periods, colnames...), df.Err)
+}
+
+// PctChangeE 与 PctChange 相同，但返回错误。
+func (df DataFrame) PctChangeE(periods int, colnames ...string) (DataFrame, error) {
+	return checked("PctChange", df.PctChange(periods, colnames...), df.Err)
+}
+
+// ShiftE 与 Shift 相同，但返回错误。
+func (df DataFrame) ShiftE(periods int, fill interface{}, colnames ...string) (DataFrame, error) {
+	return checked("Shift", df.Shift(periods, fill, colnames...), df.Err)
+}
+
+// GroupByE 与 GroupBy 相同，但返回错误。
+func (df DataFrame) GroupByE(colnames ...string) (*Groups, error) {
+	gps := df.GroupBy(colnames...)
+	return gps, checkedErr("GroupBy", gps.Err, df.Err)
+}
+
+// WindowE 与 Window 相同，但返回错误。
+func (df DataFrame) WindowE(partitionBy []string, orderBy []Order) (*Window, error) {
+	w := df.Window(partitionBy, orderBy)
+	return w, checkedErr("Window", w.Err, df.Err)
+}
+
+// AggregationE 与 Aggregation 相同，但返回错误。
+func (gps Groups) AggregationE(typs []AggregationType, colnames []string) (DataFrame, error) {
+	return checked("Aggregation", gps.Aggregation(typs, colnames), gps.Err)
+}
+
+// CumSumE 与 CumSum 相同，但返回错误。
+func (gps Groups) CumSumE(colnames ...string) (DataFrame, error) {
+	return checked("CumSum", gps.CumSum(colnames...), gps.Err)
+}
+
+// CumProdE 与 CumProd 相同，但返回错误。
+func (gps Groups) CumProdE(colnames ...string) (DataFrame, error) {
+	return checked("CumProd", gps.CumProd(colnames...), gps.Err)
+}
+
+// CumMaxE 与 CumMax 相同，但返回错误。
+func (gps Groups) CumMaxE(colnames ...string) (DataFrame, error) {
+	return checked("CumMax", gps.CumMax(colnames...), gps.Err)
+}
+
+// CumMinE 与 CumMin 相同，但返回错误。
+func (gps Groups) CumMinE(colnames ...string) (DataFrame, error) {
+	return checked("CumMin", gps.CumMin(colnames...), gps.Err)
+}
+
+// DiffE 与 Diff 相同，但返回错误。
+func (gps Groups) DiffE(periods int, colnames ...string) (DataFrame, error) {
+	return checked("Diff", gps.Diff(periods, colnames...), gps.Err)
+}
+
+// PctChangeE 与 PctChange 相同，但返回错误。
+func (gps Groups) PctChangeE(periods int, colnames ...string) (DataFrame, error) {
+	return checked("PctChange", gps.PctChange(periods, colnames...), gps.Err)
+}
+
+// ShiftE 与 Shift 相同，但返回错误。
+func (gps Groups) ShiftE(periods int, fill interface{}, colnames ...string) (DataFrame, error) {
+	return checked("Shift", gps.Shift(periods, fill, colnames...), gps.Err)
+}
+
+// AggregateE 与 Aggregate 相同，但返回错误。
+func (c *CSVChunkReader) AggregateE(typs []AggregationType, colnames []string) (DataFrame, error) {
+	return checked("Aggregate", c.Aggregate(typs, colnames))
+}
+
+// GroupAggregateE 与 GroupAggregate 相同，但返回错误。
+func (c *CSVChunkReader) GroupAggregateE(groupBy []string, typs []AggregationType, colnames []string) (DataFrame, error) {
+	return checked("GroupAggregate", c.GroupAggregate(groupBy, typs, colnames))
+}
+
+// RowNumberE 与 RowNumber 相同，但返回错误。
+func (w Window) RowNumberE() (series.Series, error) {
+	return checkedSeries("RowNumber", w.RowNumber(), w.Err)
+}
+
+// RankE 与 Rank 相同，但返回错误。
+func (w Window) RankE() (series.Series, error) {
+	return checkedSeries("Rank", w.Rank(), w.Err)
+}
+
+// DenseRankE 与 DenseRank 相同，但返回错误。
+func (w Window) DenseRankE() (series.Series, error) {
+	return checkedSeries("DenseRank", w.DenseRank(), w.Err)
+}
+
+// PercentRankE 与 PercentRank 相同，但返回错误。
+func (w Window) PercentRankE() (series.Series, error) {
+	return checkedSeries("PercentRank", w.PercentRank(), w.Err)
+}
+
+// NTileE 与 NTile 相同，但返回错误。
+func (w Window) NTileE(n int) (series.Series, error) {
+	return checkedSeries("NTile", w.NTile(n), w.Err)
+}
+
+// LagE 与 Lag 相同，但返回错误。
+func (w Window) LagE(colname string, n int) (series.Series, error) {
+	return checkedSeries("Lag", w.Lag(colname, n), w.Err)
+}
+
+// LeadE 与 Lead 相同，但返回错误。
+func (w Window) LeadE(colname string, n int) (series.Series, error) {
+	return checkedSeries("Lead", w.Lead(colname, n), w.Err)
+}
+
+// RunningE 与 Running 相同，但返回错误。
+func (w Window) RunningE(colname string, typ AggregationType) (series.Series, error) {
+	return checkedSeries("Running", w.Running(colname, typ), w.Err)
+}
+
+// CumSumE 与 CumSum 相同，但返回错误。
+func (w Window) CumSumE(colname string) (series.Series, error) {
+	return checkedSeries("CumSum", w.CumSum(colname), w.Err)
+}
+
+// CumMaxE 与 CumMax 相同，但返回错误。
+func (w Window) CumMaxE(colname string) (series.Series, error) {
+	return checkedSeries("CumMax", w.CumMax(colname), w.Err)
+}
+
+// CumMinE 与 CumMin 相同，但返回错误。
+func (w Window) CumMinE(colname string) (series.Series, error) {
+	return checkedSeries("CumMin", w.CumMin(colname), w.Err)
+}
@@ -0,0 +1,78 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestCheckedOperations(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b"}, series.String, "k"),
+		series.New([]int{1, 2}, series.Int, "v"),
+	)
+	tests := []struct {
+		name string
+		f    func() error
+		kind series.ErrorKind
+		ops  []string
+	}{
+		{"col", func() error { _, err := df.ColE("x"); return err }, ErrColumnNotFound, []string{"Col"}},
+		{"select", func() error { _, err := df.SelectE("x"); return err }, ErrColumnNotFound, []string{"Select"}},
+		{"group by", func() error { _, err := df.GroupByE("x"); return err }, ErrColumnNotFound, []string{"GroupBy"}},
+		{"window", func() error { _, err := df.Window(nil, nil).LagE("x", 1); return err }, ErrColumnNotFound, []string{"Lag"}},
+		{"cumsum", func() error { _, err := df.CumSumE("x"); return err }, ErrColumnNotFound, []string{"CumSum"}},
+		{"group cumsum", func() error { _, err := df.GroupBy("k").CumSumE("x"); return err }, ErrColumnNotFound, []string{"CumSum"}},
+		{"filter aggregation", func() error {
+			_, err := df.FilterAggregationE(And, F{Colname: "x", Comparator: series.Eq, Comparando: 1})
+			return err
+		}, ErrColumnNotFound, []string{"FilterAggregation", "Filter"}},
+		{"ntile", func() error { _, err := df.Window(nil, nil).NTileE(0); return err }, ErrInvalidArgument, []string{"NTile"}},
+		{"csv", func() error { _, err := ReadCSVE(strings.NewReader("a,b\n1")); return err }, "", []string{"ReadCSV"}},
+		{"chain", func() error {
+			_, err := df.Select("x").ArrangeE(Sort("k"))
+			return err
+		}, ErrColumnNotFound, []string{"Arrange", "Select"}},
+		{"window chain", func() error {
+			_, err := df.Window([]string{"x"}, nil).RankE()
+			return err
+		}, ErrColumnNotFound, []string{"Rank", "Window", "GroupBy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.kind != "" && !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+			if got := series.Operations(err); !reflect.DeepEqual(got, tt.ops) {
+				t.Errorf("operations = %v, want %v", got, tt.ops)
+			}
+		})
+	}
+}
+
+func TestCheckedSuccess(t *testing.T) {
+	df := New(series.New([]int{2, 1}, series.Int, "v"))
+	col, err := df.ColE("v")
+	if err != nil || col.Len() != 2 {
+		t.Fatalf("ColE = %v, %v", col, err)
+	}
+	cp, err := df.CopyE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRecords(t, cp, [][]string{{"v"}, {"2"}, {"1"}})
+	ranks, err := df.Window(nil, []Order{Sort("v")}).RowNumberE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ranks.Records(); !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Errorf("row numbers = %v", got)
+	}
+}
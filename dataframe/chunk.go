@@ -0,0 +1,355 @@
+package dataframe
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// CSVChunkReader 按块读取 CSV 数据，每次返回最多 size 行的 DataFrame，适用于无法一次性载入内存的大文件。
+// 所有块的列名和列类型相同：未通过 WithTypes 指定类型的列按照第一块数据检测类型，之后的块沿用该类型，
+// 无法转换为该类型的值会成为 NaN。
+type CSVChunkReader struct {
+	r       *csv.Reader
+	closer  io.Closer // 释放解压器
+	size    int
+	cfg     loadOptions
+	options []LoadOption
+	headers []string               // 列名
+	types   map[string]series.Type // 第一块确定的列类型
+	started bool
+	rows    int   // 已经读取的数据行数
+	Err     error // 错误信息
+}
+
+// NewCSVChunkReader 创建一个每块最多 size 行的 CSVChunkReader，支持与 ReadCSV 相同的 LoadOption。
+// SkipRows 和 NRows 作用于整个输入而不是每一块，AllowRaggedRows 报告的行索引也相对于整个输入。
+func NewCSVChunkReader(r io.Reader, size int, options ...LoadOption) *CSVChunkReader {
+	if size <= 0 {
+		return &CSVChunkReader{
+			closer: io.NopCloser(r),
+			size:   size,
+			Err:    series.NewError(series.ErrInvalidArgument, "NewCSVChunkReader", "positive_chunk_size"),
+		}
+	}
+	cfg := loadOptions{
+		delimiter:  ',',
+		lazyQuotes: false,
+		comment:    0,
+		hasHeader:  true,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	dr, closer, err := openInput(r, cfg.encoding)
+	if err != nil {
+		dr, closer = r, io.NopCloser(r)
+	}
+	c := &CSVChunkReader{
+		r:       csv.NewReader(dr),
+		closer:  closer,
+		size:    size,
+		cfg:     cfg,
+		options: options,
+	}
+	if err != nil {
+		c.Err = series.WrapError("NewCSVChunkReader", err)
+	}
+	c.r.Comma = cfg.delimiter
+	c.r.LazyQuotes = cfg.lazyQuotes
+	c.r.Comment = cfg.comment
+	if cfg.ragged {
+		c.r.FieldsPerRecord = -1
+	}
+	return c
+}
+
+// Close 释放解压压缩输入时占用的资源，不会关闭传给 NewCSVChunkReader 的输入。
+func (c *CSVChunkReader) Close() error {
+	return c.closer.Close()
+}
+
+// Names 返回块的列名，在读取第一块之前返回 nil。
+func (c *CSVChunkReader) Names() []string {
+	return c.headers
+}
+
+// Types 返回块的列类型，在读取第一块之前返回 nil。
+func (c *CSVChunkReader) Types() map[string]series.Type {
+	return c.types
+}
+
+// Next 读取并返回下一块数据。没有更多数据时返回 io.EOF，发生其他错误时返回该错误并同时设置 Err。
+func (c *CSVChunkReader) Next() (DataFrame, error) {
+	if c.Err != nil {
+		return DataFrame{Err: c.Err}, c.Err
+	}
+	if !c.started {
+		c.started = true
+		if err := c.readHeader(); err != nil {
+			return c.fail(err)
+		}
+	}
+
+	size := c.size
+	if c.cfg.nrows > 0 && c.cfg.nrows-c.rows < size {
+		size = c.cfg.nrows - c.rows
+	}
+	var records [][]string
+	for len(records) < size {
+		record, err := c.r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return c.fail(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return DataFrame{}, io.EOF
+	}
+
+	if c.headers == nil {
+		// 没有表头时使用与 LoadRecords 相同的默认列名，保证每一块的列名一致
+		c.headers = make([]string, len(records[0]))
+		fixColnames(c.headers)
+	}
+
+	var ragged []RowError
+	options := append(append([]LoadOption(nil), c.options...), HasHeader(false), Names(c.headers...), SkipRows(0), NRows(0))
+	if c.cfg.ragged {
+		options = append(options, AllowRaggedRows(&ragged))
+	}
+	if c.types != nil {
+		options = append(options, WithTypes(c.types))
+	}
+	df := LoadRecords(records, options...)
+	if df.Err != nil {
+		return c.fail(df.Err)
+	}
+	if c.cfg.raggedRows != nil {
+		for _, e := range ragged {
+			e.Row += c.rows
+			*c.cfg.raggedRows = append(*c.cfg.raggedRows, e)
+		}
+	}
+	c.rows += len(records)
+	if c.types == nil {
+		c.types = make(map[string]series.Type, df.ncols)
+		for _, col := range df.columns {
+			c.types[col.Name] = col.Type()
+		}
+	}
+	return df, nil
+}
+
+// readHeader 读取表头并确定列名。
+func (c *CSVChunkReader) readHeader() error {
+	for i := 0; i < c.cfg.skipRows; i++ {
+		if _, err := c.r.Read(); err != nil {
+			if err == io.EOF {
+				return series.NewError(series.ErrEmpty, "", "empty_dataframe")
+			}
+			return err
+		}
+	}
+	if c.cfg.hasHeader {
+		record, err := c.r.Read()
+		if err == io.EOF {
+			return series.NewError(series.ErrEmpty, "", "empty_dataframe")
+		}
+		if err != nil {
+			return err
+		}
+		c.headers = append([]string(nil), record...)
+	}
+	if c.cfg.names != nil {
+		if c.headers != nil && len(c.cfg.names) != len(c.headers) {
+			if len(c.cfg.names) > len(c.headers) {
+				return series.NewError(series.ErrDimensionMismatch, "", "too_many_names")
+			}
+			return series.NewError(series.ErrDimensionMismatch, "", "too_few_names")
+		}
+		c.headers = c.cfg.names
+	}
+	return nil
+}
+
+// fail 记录错误并返回。
+func (c *CSVChunkReader) fail(err error) (DataFrame, error) {
+	c.Err = series.WrapError("ReadCSVChunk", err)
+	return DataFrame{Err: c.Err}, c.Err
+}
+
+// Each 依次对剩余的每一块数据调用 f，f 返回错误时停止读取并返回该错误。
+func (c *CSVChunkReader) Each(f func(DataFrame) error) error {
+	for {
+		df, err := c.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(df); err != nil {
+			return err
+		}
+	}
+}
+
+// Aggregate 逐块读取剩余的数据并对 colnames 中的列计算聚合值，返回只有一行的DataFrame，
+// 列名为 "<列名>_<聚合类型>"。不支持需要全部数据的 Aggregation_MEDIAN。
+func (c *CSVChunkReader) Aggregate(typs []AggregationType, colnames []string) DataFrame {
+	return c.GroupAggregate(nil, typs, colnames)
+}
+
+// GroupAggregate 逐块读取剩余的数据，按 groupBy 中的列分组并对 colnames 中的列计算聚合值，
+// 结果与 GroupBy(groupBy...).Aggregation(typs, colnames) 相同，但内存中只保留当前块和每个分组的累计状态。
+// 不支持需要全部数据的 Aggregation_MEDIAN；包含 NaN 的列除 Aggregation_COUNT 外的聚合结果为 NaN。
+func (c *CSVChunkReader) GroupAggregate(groupBy []string, typs []AggregationType, colnames []string) DataFrame {
+	if len(typs) != len(colnames) {
+		return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "GroupAggregate", "aggregation_length")}
+	}
+	for _, typ := range typs {
+		if typ == Aggregation_MEDIAN {
+			return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "GroupAggregate", "streaming_median")}
+		}
+	}
+
+	var keys []string
+	groups := make(map[string]*chunkGroup)
+	err := c.Each(func(df DataFrame) error {
+		indices := map[string][]int{"": nil}
+		if len(groupBy) > 0 {
+			gps := df.GroupBy(groupBy...)
+			if gps.Err != nil {
+				return gps.Err
+			}
+			indices = gps.indices
+		}
+		// 新的分组按其在数据中首次出现的顺序排列
+		chunkKeys := make([]string, 0, len(indices))
+		for k := range indices {
+			chunkKeys = append(chunkKeys, k)
+		}
+		sort.Slice(chunkKeys, func(i, j int) bool {
+			a, b := indices[chunkKeys[i]], indices[chunkKeys[j]]
+			return len(a) > 0 && (len(b) == 0 || a[0] < b[0])
+		})
+
+		cols := make([]series.Series, len(colnames))
+		for i, colname := range colnames {
+			idx := df.colIndex(colname)
+			if idx < 0 {
+				return series.ColumnNotFoundError("GroupAggregate", colname)
+			}
+			cols[i] = df.columns[idx]
+		}
+		for _, k := range chunkKeys {
+			rows := indices[k]
+			g, ok := groups[k]
+			if !ok {
+				g = &chunkGroup{values: make(map[string]interface{}), accs: make([]accumulator, len(colnames))}
+				if len(rows) > 0 {
+					for _, colname := range groupBy {
+						g.values[colname] = df.columns[df.colIndex(colname)].Val(rows[0])
+					}
+				}
+				groups[k] = g
+				keys = append(keys, k)
+			}
+			for i, col := range cols {
+				if rows == nil {
+					for j := 0; j < col.Len(); j++ {
+						g.accs[i].add(col.Elem(j).Float())
+					}
+					continue
+				}
+				for _, j := range rows {
+					g.accs[i].add(col.Elem(j).Float())
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return DataFrame{Err: series.WrapError("GroupAggregate", err)}
+	}
+	if len(keys) == 0 {
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "GroupAggregate", "empty_dataframe")}
+	}
+
+	colTypes := map[string]series.Type{}
+	for colname, t := range c.types {
+		colTypes[colname] = t
+	}
+	maps := make([]map[string]interface{}, len(keys))
+	for r, k := range keys {
+		g := groups[k]
+		m := make(map[string]interface{}, len(g.values)+len(typs))
+		for colname, v := range g.values {
+			m[colname] = v
+		}
+		for i, colname := range colnames {
+			name := colname + "_" + typs[i].String()
+			m[name] = g.accs[i].value(typs[i])
+			colTypes[name] = series.Float
+		}
+		maps[r] = m
+	}
+	return LoadMaps(maps, WithTypes(colTypes))
+}
+
+// chunkGroup 保存一个分组的分组列取值和各列的累计状态。
+type chunkGroup struct {
+	values map[string]interface{}
+	accs   []accumulator
+}
+
+// accumulator 以流式方式累计一列数据的统计量。
+type accumulator struct {
+	n        int
+	sum      float64
+	mean, m2 float64 // 使用 Welford 算法计算方差
+	min, max float64
+}
+
+// add 将 x 加入累计状态。
+func (a *accumulator) add(x float64) {
+	a.n++
+	a.sum += x
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min = math.Min(a.min, x)
+		a.max = math.Max(a.max, x)
+	}
+	d := x - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (x - a.mean)
+}
+
+// value 返回指定聚合类型的结果。
+func (a *accumulator) value(typ AggregationType) float64 {
+	switch typ {
+	case Aggregation_MAX:
+		return a.max
+	case Aggregation_MIN:
+		return a.min
+	case Aggregation_MEAN:
+		return a.mean
+	case Aggregation_STD:
+		if a.n < 2 {
+			return math.NaN()
+		}
+		return math.Sqrt(a.m2 / float64(a.n-1))
+	case Aggregation_SUM:
+		return a.sum
+	case Aggregation_COUNT:
+		return float64(a.n)
+	}
+	return math.NaN()
+}
@@ -0,0 +1,128 @@
+package dataframe
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestCSVChunkReader(t *testing.T) {
+	const data = "k,v\na,1\nb,2\na,3\nb,x\na,5\n"
+	tests := []struct {
+		name    string
+		size    int
+		options []LoadOption
+		want    [][][]string
+	}{
+		{"exact", 5, nil, [][][]string{
+			{{"k", "v"}, {"a", "1"}, {"b", "2"}, {"a", "3"}, {"b", "x"}, {"a", "5"}},
+		}},
+		{"chunks", 2, nil, [][][]string{
+			{{"k", "v"}, {"a", "1"}, {"b", "2"}},
+			{{"k", "v"}, {"a", "3"}, {"b", "NaN"}},
+			{{"k", "v"}, {"a", "5"}},
+		}},
+		{"skip and limit", 2, []LoadOption{HasHeader(false), SkipRows(2), NRows(3)}, [][][]string{
+			{{"X0", "X1"}, {"b", "2"}, {"a", "3"}},
+			{{"X0", "X1"}, {"b", "NaN"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCSVChunkReader(strings.NewReader(data), tt.size, tt.options...)
+			defer c.Close()
+			var got [][][]string
+			var types []map[string]series.Type
+			err := c.Each(func(df DataFrame) error {
+				got = append(got, df.Records())
+				m := map[string]series.Type{}
+				for i, name := range df.Names() {
+					m[name] = df.Types()[i]
+				}
+				types = append(types, m)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunks = %v, want %v", got, tt.want)
+			}
+			for i := range types {
+				if !reflect.DeepEqual(types[i], c.Types()) {
+					t.Errorf("chunk %d types = %v, want %v", i, types[i], c.Types())
+				}
+			}
+			if _, err := c.Next(); err != io.EOF {
+				t.Errorf("Next after end = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestCSVChunkReaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		options []LoadOption
+		kind    series.ErrorKind
+	}{
+		{"zero size", 0, nil, ErrInvalidArgument},
+		{"negative size", -1, nil, ErrInvalidArgument},
+		{"empty", 2, []LoadOption{SkipRows(10)}, ErrEmpty},
+		{"names", 2, []LoadOption{Names("a", "b", "c")}, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCSVChunkReader(strings.NewReader("k,v\na,1\n"), tt.size, tt.options...)
+			_, err := c.Next()
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+			if c.Err == nil {
+				t.Error("Err not set")
+			}
+		})
+	}
+
+	t.Run("encoding", func(t *testing.T) {
+		c := NewCSVChunkReader(strings.NewReader("k\n1\n"), 1, WithEncoding("no-such-encoding"))
+		if _, err := c.Next(); err == nil || err != c.Err {
+			t.Errorf("err = %v, Err = %v", err, c.Err)
+		}
+	})
+}
+
+func TestCSVChunkReaderAggregate(t *testing.T) {
+	const data = "k,v\na,1\nb,2\na,3\nb,4\na,5\n"
+	whole := ReadCSV(strings.NewReader(data)).
+		GroupBy("k").Aggregation([]AggregationType{Aggregation_SUM, Aggregation_COUNT}, []string{"v", "v"})
+	got := NewCSVChunkReader(strings.NewReader(data), 2).
+		GroupAggregate([]string{"k"}, []AggregationType{Aggregation_SUM, Aggregation_COUNT}, []string{"v", "v"})
+	if got.Err != nil {
+		t.Fatal(got.Err)
+	}
+	if !reflect.DeepEqual(got.Arrange(Sort("k")).Records(), whole.Arrange(Sort("k")).Records()) {
+		t.Errorf("GroupAggregate = %v, want %v", got.Records(), whole.Records())
+	}
+
+	total := NewCSVChunkReader(strings.NewReader(data), 2).
+		Aggregate([]AggregationType{Aggregation_MAX, Aggregation_MEAN}, []string{"v", "v"})
+	checkRecords(t, total, [][]string{{"v_MAX", "v_MEAN"}, {"5.000000", "3.000000"}})
+
+	median := NewCSVChunkReader(strings.NewReader(data), 2).
+		Aggregate([]AggregationType{Aggregation_MEDIAN}, []string{"v"})
+	if !errors.Is(median.Err, ErrInvalidArgument) {
+		t.Errorf("median err = %v, want %v", median.Err, ErrInvalidArgument)
+	}
+
+	missing := NewCSVChunkReader(strings.NewReader(data), 2).
+		Aggregate([]AggregationType{Aggregation_SUM}, []string{"x"})
+	if !errors.Is(missing.Err, ErrColumnNotFound) {
+		t.Errorf("missing err = %v, want %v", missing.Err, ErrColumnNotFound)
+	}
+}
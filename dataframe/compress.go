@@ -0,0 +1,221 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+	"io"
+	"os"
+	"path/filepath"
+	"stream/go-sdk/test/gota_study/series"
+	"strings"
+)
+
+// Compression 表示输出使用的压缩格式。
+type Compression string
+
+// 支持的压缩格式
+const (
+	NoCompression Compression = ""      // 不压缩
+	Gzip          Compression = "gzip"  // gzip，扩展名 .gz
+	Zstd          Compression = "zstd"  // Zstandard，扩展名 .zst
+	Bzip2         Compression = "bzip2" // bzip2，扩展名 .bz2，只支持读取
+	Xz            Compression = "xz"    // xz，扩展名 .xz
+)
+
+// 各压缩格式的魔数
+var (
+	gzipMagic  = []byte{0x1f, 0x8b, 0x08}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	bzip2Magic = []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59} // "BZh" 和块大小之后的块头
+	xzMagic    = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+)
+
+// WriteCompression 函数返回一个WriteOption，用于设置输出的压缩格式。
+// 使用 WriteCSVFile 和 WriteJSONFile 时，未设置压缩格式则根据文件扩展名确定。
+func WriteCompression(c Compression) WriteOption {
+	return func(o *writeOptions) {
+		o.compression = c
+		o.compressionSet = true
+	}
+}
+
+// decompressReader 根据输入开头的魔数识别压缩格式并返回解压后的输入，未压缩的输入原样返回。
+// 读取完成后应调用 Close 释放解压器占用的资源，Close 不会关闭 r。
+func decompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(10)
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(magic, zstdMagic):
+		d, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case len(magic) == 10 && bytes.HasPrefix(magic, []byte("BZh")) &&
+		magic[3] >= '1' && magic[3] <= '9' && bytes.Equal(magic[4:], bzip2Magic):
+		return io.NopCloser(bzip2.NewReader(br)), nil
+	case bytes.HasPrefix(magic, xzMagic):
+		xr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xr), nil
+	}
+	return io.NopCloser(br), nil
+}
+
+// compressWriter 返回将输出按压缩格式 c 压缩后写入 w 的 io.WriteCloser。
+// 写入完成后必须调用 Close 以输出剩余的数据，Close 不会关闭 w。
+func compressWriter(w io.Writer, c Compression) (io.WriteCloser, error) {
+	switch c {
+	case NoCompression:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	case Xz:
+		return xz.NewWriter(w)
+	}
+	return nil, series.NewError(series.ErrInvalidArgument, "", "write_compression", c)
+}
+
+// compressionFromPath 根据文件扩展名确定压缩格式。
+func compressionFromPath(path string) Compression {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz", ".gzip":
+		return Gzip
+	case ".zst", ".zstd":
+		return Zstd
+	case ".bz2":
+		return Bzip2
+	case ".xz":
+		return Xz
+	}
+	return NoCompression
+}
+
+// ReadCSVFile 从文件读取 CSV 格式的 DataFrame，压缩的文件会被自动解压。
+func ReadCSVFile(path string, options ...LoadOption) DataFrame {
+	f, err := os.Open(path)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("ReadCSVFile", err)}
+	}
+	defer f.Close()
+	return ReadCSV(f, options...)
+}
+
+// ReadJSONFile 从文件读取 JSON 格式的 DataFrame，压缩的文件会被自动解压。
+func ReadJSONFile(path string, options ...LoadOption) DataFrame {
+	f, err := os.Open(path)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("ReadJSONFile", err)}
+	}
+	defer f.Close()
+	return ReadJSON(f, options...)
+}
+
+// WriteCSVFile 将 DataFrame 以 CSV 格式写入文件。未通过 WriteCompression 设置压缩格式时，
+// 根据文件扩展名 (.gz、.zst、.xz) 确定压缩格式。不支持的压缩格式或编码不会创建文件，写入失败时删除文件。
+func (df DataFrame) WriteCSVFile(path string, options ...WriteOption) error {
+	return writeFile("WriteCSV", path, options, df.WriteCSV)
+}
+
+// WriteJSONFile 将 DataFrame 以 JSON 格式写入文件，压缩格式的确定方式和出错时的处理与 WriteCSVFile 相同。
+func (df DataFrame) WriteJSONFile(path string, options ...WriteOption) error {
+	return writeFile("WriteJSON", path, options, df.WriteJSON)
+}
+
+// writeFile 创建文件并使用 write 写入，未设置压缩格式时根据扩展名确定。
+// 创建文件之前检查压缩格式和编码，写入失败时删除已创建的文件。
+func writeFile(op, path string, options []WriteOption, write func(io.Writer, ...WriteOption) error) error {
+	cfg := writeOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if !cfg.compressionSet {
+		cfg.compression = compressionFromPath(path)
+		options = append(append([]WriteOption(nil), options...), WriteCompression(cfg.compression))
+	}
+	if err := checkOutput(cfg); err != nil {
+		return series.WrapError(op, err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = write(f, options...)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// checkOutput 检查是否支持写入 cfg 中的压缩格式和编码。
+func checkOutput(cfg writeOptions) error {
+	switch cfg.compression {
+	case NoCompression, Gzip, Zstd, Xz:
+	default:
+		return series.NewError(series.ErrInvalidArgument, "", "write_compression", cfg.compression)
+	}
+	if cfg.encoding != "" {
+		if _, err := lookupEncoding(cfg.encoding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openInput 依次对输入解压和解码，返回 UTF-8 的输入。读取完成后应调用返回的 io.Closer 释放解压器。
+func openInput(r io.Reader, encoding string) (io.Reader, io.Closer, error) {
+	zr, err := decompressReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	dr, err := decodeReader(zr, encoding)
+	if err != nil {
+		zr.Close()
+		return nil, nil, err
+	}
+	return dr, zr, nil
+}
+
+// openOutput 返回将 UTF-8 输出依次编码和压缩后写入 w 的 io.WriteCloser。
+// 写入完成后必须调用 Close 以输出剩余的数据，Close 不会关闭 w。
+func openOutput(w io.Writer, cfg writeOptions) (io.WriteCloser, error) {
+	zw, err := compressWriter(w, cfg.compression)
+	if err != nil {
+		return nil, err
+	}
+	ew, err := encodeWriter(zw, cfg.encoding, cfg.bom)
+	if err != nil {
+		zw.Close()
+		return nil, err
+	}
+	return outputWriter{ew, zw}, nil
+}
+
+// outputWriter 关闭时先关闭编码器再关闭压缩器。
+type outputWriter struct {
+	io.WriteCloser
+	zw io.WriteCloser
+}
+
+// Close 输出编码器和压缩器中剩余的数据。
+func (o outputWriter) Close() error {
+	if err := o.WriteCloser.Close(); err != nil {
+		o.zw.Close()
+		return err
+	}
+	return o.zw.Close()
+}
@@ -0,0 +1,127 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	df := ReadCSV(strings.NewReader("a,b\n1,x\n2,y\n"))
+	want := df.Records()
+	for _, c := range []Compression{NoCompression, Gzip, Zstd, Xz} {
+		t.Run("csv "+string(c), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := df.WriteCSV(&buf, WriteCompression(c)); err != nil {
+				t.Fatal(err)
+			}
+			checkRecords(t, ReadCSV(&buf), want)
+		})
+		t.Run("json "+string(c), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := df.WriteJSON(&buf, WriteCompression(c)); err != nil {
+				t.Fatal(err)
+			}
+			checkRecords(t, ReadJSON(&buf), want)
+		})
+	}
+}
+
+func TestCompressionFiles(t *testing.T) {
+	df := ReadCSV(strings.NewReader("a,b\n1,x\n2,y\n"))
+	dir := t.TempDir()
+	tests := []struct {
+		file  string
+		magic []byte
+	}{
+		{"plain.csv", []byte("a,b")},
+		{"data.csv.gz", gzipMagic},
+		{"data.csv.zst", zstdMagic},
+		{"data.csv.xz", xzMagic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := df.WriteCSVFile(path); err != nil {
+				t.Fatal(err)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(b, tt.magic) {
+				t.Errorf("file starts with % x, want % x", b[:len(tt.magic)], tt.magic)
+			}
+			checkRecords(t, ReadCSVFile(path), df.Records())
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(dir, "data.json.gz")
+		if err := df.WriteJSONFile(path); err != nil {
+			t.Fatal(err)
+		}
+		checkRecords(t, ReadJSONFile(path), df.Records())
+	})
+
+	t.Run("explicit compression", func(t *testing.T) {
+		path := filepath.Join(dir, "data.out")
+		if err := df.WriteCSVFile(path, WriteCompression(Gzip)); err != nil {
+			t.Fatal(err)
+		}
+		checkRecords(t, ReadCSVFile(path), df.Records())
+	})
+}
+
+func TestCompressionWriteErrors(t *testing.T) {
+	df := ReadCSV(strings.NewReader("a\n1\n"))
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		options []WriteOption
+	}{
+		{"bzip2 extension", "data.csv.bz2", nil},
+		{"bzip2 option", "data.csv", []WriteOption{WriteCompression(Bzip2)}},
+		{"unknown compression", "data.csv", []WriteOption{WriteCompression("lz4")}},
+		{"unknown encoding", "data.csv", []WriteOption{WriteEncoding("no-such-encoding")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			err := df.WriteCSVFile(path, tt.options...)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidArgument)
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("file %s exists after failed write", tt.file)
+			}
+		})
+	}
+
+	t.Run("failed write removes file", func(t *testing.T) {
+		path := filepath.Join(dir, "failed.csv")
+		bad := DataFrame{Err: errors.New("bad frame")}
+		if err := bad.WriteCSVFile(path); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Error("file exists after failed write")
+		}
+	})
+}
+
+func TestReadBzip2(t *testing.T) {
+	// printf 'a,b\n1,x\n' | bzip2
+	data := []byte{
+		0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xd4, 0x7b,
+		0xf1, 0x6e, 0x00, 0x00, 0x02, 0xd9, 0x80, 0x00, 0x10, 0x00, 0x04, 0x20,
+		0x00, 0x30, 0x00, 0x00, 0x40, 0x20, 0x00, 0x21, 0xa6, 0x99, 0xa0, 0xc0,
+		0x28, 0x15, 0x0b, 0x0b, 0xb9, 0x22, 0x9c, 0x28, 0x48, 0x6a, 0x3d, 0xf8,
+		0xb7, 0x00,
+	}
+	checkRecords(t, ReadCSV(bytes.NewReader(data)), [][]string{{"a", "b"}, {"1", "x"}})
+}
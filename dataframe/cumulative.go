@@ -0,0 +1,171 @@
+package dataframe
+
+import "stream/go-sdk/test/gota_study/series"
+
+// CumSum 返回对指定列计算累计和后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) CumSum(colnames ...string) DataFrame {
+	return df.transform("CumSum", series.Series.CumSum, colnames, true)
+}
+
+// CumProd 返回对指定列计算累计积后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) CumProd(colnames ...string) DataFrame {
+	return df.transform("CumProd", series.Series.CumProd, colnames, true)
+}
+
+// CumMax 返回对指定列计算累计最大值后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) CumMax(colnames ...string) DataFrame {
+	return df.transform("CumMax", series.Series.CumMax, colnames, true)
+}
+
+// CumMin 返回对指定列计算累计最小值后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) CumMin(colnames ...string) DataFrame {
+	return df.transform("CumMin", series.Series.CumMin, colnames, true)
+}
+
+// Diff 返回对指定列计算 periods 阶差分后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) Diff(periods int, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.Diff(periods) }
+	return df.transform("Diff", f, colnames, true)
+}
+
+// PctChange 返回对指定列计算变化率后的新DataFrame。未指定列名时处理所有数值列。
+func (df DataFrame) PctChange(periods int, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.PctChange(periods) }
+	return df.transform("PctChange", f, colnames, true)
+}
+
+// Shift 返回将指定列移动 periods 行后的新DataFrame，空出的位置使用 fill 填充。
+// 未指定列名时处理所有列。
+func (df DataFrame) Shift(periods int, fill interface{}, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.Shift(periods, fill) }
+	return df.transform("Shift", f, colnames, false)
+}
+
+// transform 对指定列应用 f 并用结果替换原列。未指定列名时，numeric 为 true 则处理所有
+// 数值列（整数类型、Float 和 Decimal），否则处理所有列。
+func (df DataFrame) transform(op string, f func(series.Series) series.Series, colnames []string, numeric bool) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idx, err := df.transformIndexes(colnames, numeric)
+	if err != nil {
+		return DataFrame{Err: series.WrapError(op, err)}
+	}
+	columns := make([]series.Series, df.ncols)
+	copy(columns, df.columns)
+	for _, i := range idx {
+		s := f(df.columns[i])
+		if s.Err != nil {
+			return DataFrame{Err: columnError(op, df.columns[i].Name, s.Err)}
+		}
+		s.Name = df.columns[i].Name
+		columns[i] = s
+	}
+	return New(columns...)
+}
+
+// transformIndexes 返回需要变换的列索引。
+func (df DataFrame) transformIndexes(colnames []string, numeric bool) ([]int, error) {
+	var idx []int
+	if len(colnames) == 0 {
+		for i, s := range df.columns {
+			if !numeric || series.IsNumeric(s.Type()) {
+				idx = append(idx, i)
+			}
+		}
+		return idx, nil
+	}
+	for _, c := range colnames {
+		i := df.colIndex(c)
+		if i < 0 {
+			return nil, series.ColumnNotFoundError("", c)
+		}
+		idx = append(idx, i)
+	}
+	return idx, nil
+}
+
+// CumSum 在每个分组内计算指定列的累计和，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) CumSum(colnames ...string) DataFrame {
+	return gps.transform("CumSum", series.Series.CumSum, colnames, true)
+}
+
+// CumProd 在每个分组内计算指定列的累计积，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) CumProd(colnames ...string) DataFrame {
+	return gps.transform("CumProd", series.Series.CumProd, colnames, true)
+}
+
+// CumMax 在每个分组内计算指定列的累计最大值，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) CumMax(colnames ...string) DataFrame {
+	return gps.transform("CumMax", series.Series.CumMax, colnames, true)
+}
+
+// CumMin 在每个分组内计算指定列的累计最小值，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) CumMin(colnames ...string) DataFrame {
+	return gps.transform("CumMin", series.Series.CumMin, colnames, true)
+}
+
+// Diff 在每个分组内计算指定列的 periods 阶差分，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) Diff(periods int, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.Diff(periods) }
+	return gps.transform("Diff", f, colnames, true)
+}
+
+// PctChange 在每个分组内计算指定列的变化率，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) PctChange(periods int, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.PctChange(periods) }
+	return gps.transform("PctChange", f, colnames, true)
+}
+
+// Shift 在每个分组内将指定列移动 periods 行，返回与原始DataFrame行对齐的新DataFrame。
+func (gps Groups) Shift(periods int, fill interface{}, colnames ...string) DataFrame {
+	f := func(s series.Series) series.Series { return s.Shift(periods, fill) }
+	return gps.transform("Shift", f, colnames, false)
+}
+
+// transform 在每个分组内对指定列应用 f，并按分组的行索引把结果写回原始位置。
+// 分组列本身不会被变换。
+func (gps Groups) transform(op string, f func(series.Series) series.Series, colnames []string, numeric bool) DataFrame {
+	if gps.Err != nil {
+		return DataFrame{Err: gps.Err}
+	}
+	if gps.indices == nil {
+		return DataFrame{Err: series.NewError(series.ErrEmpty, op, "nil_input")}
+	}
+	df := gps.df
+	idx, err := df.transformIndexes(colnames, numeric)
+	if err != nil {
+		return DataFrame{Err: series.WrapError(op, err)}
+	}
+	columns := make([]series.Series, df.ncols)
+	copy(columns, df.columns)
+	for _, i := range idx {
+		col := df.columns[i]
+		if len(colnames) == 0 && findInStringSlice(col.Name, gps.colnames) != -1 {
+			continue
+		}
+		var ret series.Series
+		first := true
+		for _, rows := range gps.indices {
+			s := f(col.Subset(rows))
+			if s.Err != nil {
+				return DataFrame{Err: columnError(op, col.Name, s.Err)}
+			}
+			if first {
+				// 使用 s 的空副本保留 Decimal 的规格等类型信息
+				ret = s.Empty()
+				ret.Name = col.Name
+				ret.Append(make([]interface{}, df.nrows))
+				first = false
+			}
+			ret = ret.Set(rows, s)
+			if ret.Err != nil {
+				return DataFrame{Err: columnError(op, col.Name, ret.Err)}
+			}
+		}
+		if !first {
+			columns[i] = ret
+		}
+	}
+	return New(columns...)
+}
@@ -0,0 +1,102 @@
+package dataframe
+
+import (
+	"errors"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestCumulative(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "b"}, series.String, "k"),
+		series.New([]int{1, 2, 3, 5}, series.Int, "v"),
+		series.New([]float64{1, 2, 4, 8}, series.Float, "f"),
+	)
+	tests := []struct {
+		name string
+		got  DataFrame
+		want [][]string
+	}{
+		{"cumsum all numeric", df.CumSum(), [][]string{
+			{"k", "v", "f"},
+			{"a", "1", "1.000000"},
+			{"b", "3", "3.000000"},
+			{"a", "6", "7.000000"},
+			{"b", "11", "15.000000"},
+		}},
+		{"diff one column", df.Diff(1, "v"), [][]string{
+			{"k", "v", "f"},
+			{"a", "NaN", "1.000000"},
+			{"b", "1", "2.000000"},
+			{"a", "1", "4.000000"},
+			{"b", "2", "8.000000"},
+		}},
+		{"shift all columns", df.Shift(1, nil), [][]string{
+			{"k", "v", "f"},
+			{"NaN", "NaN", "NaN"},
+			{"a", "1", "1.000000"},
+			{"b", "2", "2.000000"},
+			{"a", "3", "4.000000"},
+		}},
+		{"group diff", df.GroupBy("k").Diff(1), [][]string{
+			{"k", "v", "f"},
+			{"a", "NaN", "NaN"},
+			{"b", "NaN", "NaN"},
+			{"a", "2", "3.000000"},
+			{"b", "3", "6.000000"},
+		}},
+		{"group cumsum", df.GroupBy("k").CumSum("v"), [][]string{
+			{"k", "v", "f"},
+			{"a", "1", "1.000000"},
+			{"b", "2", "2.000000"},
+			{"a", "4", "4.000000"},
+			{"b", "7", "8.000000"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRecords(t, tt.got, tt.want)
+		})
+	}
+}
+
+func TestCumulativeNumericTypes(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a"}, series.String, "k"),
+		series.New([]int{100, 1, 27}, series.Int8, "i"),
+		series.New([]string{"0.10", "0.20", "0.05"}, series.DecimalOf(5, 2), "d"),
+	)
+	got := df.CumSum()
+	if !errors.Is(got.Err, ErrConversion) {
+		t.Errorf("int8 overflow err = %v, want %v", got.Err, ErrConversion)
+	}
+
+	checkRecords(t, df.GroupBy("k").CumSum(), [][]string{
+		{"k", "i", "d"},
+		{"a", "100", "0.10"},
+		{"b", "1", "0.20"},
+		{"a", "127", "0.15"},
+	})
+	grouped := df.GroupBy("k").CumSum("d").Col("d")
+	grouped.Append([]string{"0.125"})
+	if got := grouped.Elem(3).String(); got != "0.12" {
+		t.Errorf("grouped cumsum appended %v, want 0.12 (decimal(5,2) spec kept)", got)
+	}
+	checkRecords(t, df.Diff(1), [][]string{
+		{"k", "i", "d"},
+		{"a", "NaN", "NaN"},
+		{"b", "-99", "0.10"},
+		{"a", "26", "-0.15"},
+	})
+}
+
+func TestCumulativeErrors(t *testing.T) {
+	df := New(series.New([]string{"a"}, series.String, "k"))
+	if got := df.CumSum("x"); !errors.Is(got.Err, ErrColumnNotFound) {
+		t.Errorf("missing column: err = %v, want ErrColumnNotFound", got.Err)
+	}
+	if got := df.CumSum("k"); !errors.Is(got.Err, ErrUnknownType) {
+		t.Errorf("string column: err = %v, want ErrUnknownType", got.Err)
+	}
+}
@@ -3,6 +3,7 @@ package dataframe
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
@@ -12,7 +13,6 @@ import (
 	"strconv"
 	"stream/go-sdk/test/gota_study/series"
 	"strings"
-	"unicode/utf8"
 )
 
 // DataFrame 是一个表示带有命名列的数据表的数据结构。
@@ -29,7 +29,7 @@ type DataFrame struct {
 // 它检查错误，复制系列，并初始化 DataFrame。
 func New(se ...series.Series) DataFrame {
 	if se == nil || len(se) == 0 {
-		return DataFrame{Err: fmt.Errorf("empty DataFrame")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "New", "empty_dataframe")}
 	}
 
 	columns := make([]series.Series, len(se))
@@ -60,19 +60,19 @@ func checkColumnsDimensions(se ...series.Series) (nrows, ncols int, err error) {
 	ncols = len(se)
 	nrows = -1
 	if se == nil || ncols == 0 {
-		err = fmt.Errorf("no Series given")
+		err = series.NewError(series.ErrEmpty, "New", "no_series")
 		return
 	}
 	for i, s := range se {
 		if s.Err != nil {
-			err = fmt.Errorf("error on series %d: %v", i, s.Err)
+			err = series.NewError(series.ErrInvalidArgument, "New", "series_has_errors", i).Wrap(s.Err)
 			return
 		}
 		if nrows == -1 {
 			nrows = s.Len()
 		}
 		if nrows != s.Len() {
-			err = fmt.Errorf("arguments have different dimensions")
+			err = series.NewError(series.ErrDimensionMismatch, "New", "different_dimensions")
 			return
 		}
 	}
@@ -88,9 +88,9 @@ func (df DataFrame) Copy() DataFrame {
 	return copy
 }
 
-// String 返回 DataFrame 的字符串表示。
+// String 返回 DataFrame 的字符串表示，使用全局的显示选项。
 func (df DataFrame) String() (str string) {
-	return df.print(true, true, true, true, 10, 70, "DataFrame")
+	return df.print(true, true, true, true, currentDisplayOptions(), "DataFrame")
 }
 
 // Error 返回与 DataFrame 相关联的错误。
@@ -101,24 +101,33 @@ func (df *DataFrame) Error() error {
 // print 生成 DataFrame 的格式化字符串表示。
 func (df DataFrame) print(
 	shortRows, shortCols, showDims, showTypes bool,
-	maxRows int,
-	maxCharsTotal int,
+	opts displayOptions,
 	class string) (str string) {
 
 	addRightPadding := func(s string, nchar int) string {
-		if utf8.RuneCountInString(s) < nchar {
-			return s + strings.Repeat(" ", nchar-utf8.RuneCountInString(s))
+		if w := displayWidth(s); w < nchar {
+			return s + strings.Repeat(" ", nchar-w)
 		}
 		return s
 	}
 
 	addLeftPadding := func(s string, nchar int) string {
-		if utf8.RuneCountInString(s) < nchar {
-			return strings.Repeat(" ", nchar-utf8.RuneCountInString(s)) + s
+		if w := displayWidth(s); w < nchar {
+			return strings.Repeat(" ", nchar-w) + s
 		}
 		return s
 	}
 
+	formatElem := func(e series.Element) string {
+		if e.IsNA() {
+			return opts.naRep
+		}
+		if e.Type() == series.Float && opts.floatPrecision >= 0 {
+			return strconv.FormatFloat(e.Float(), 'f', opts.floatPrecision, 64)
+		}
+		return e.String()
+	}
+
 	if df.Err != nil {
 		str = fmt.Sprintf("%s error: %v", class, df.Err)
 		return
@@ -128,36 +137,48 @@ func (df DataFrame) print(
 		str = fmt.Sprintf("Empty %s", class)
 		return
 	}
-	idx := make([]int, maxRows)
-	for i := 0; i < len(idx); i++ {
-		idx[i] = i
+
+	// 选择要显示的行，dotsAt 为省略行插入的位置
+	rows := make([]int, nrows)
+	for i := range rows {
+		rows[i] = i
 	}
-	var records [][]string
 	shortening := false
-	if shortRows && nrows > maxRows {
+	dotsAt := -1
+	if shortRows && opts.maxRows >= 0 && nrows > opts.maxRows {
 		shortening = true
-		df = df.Subset(idx)
-		records = df.Records()
-	} else {
-		records = df.Records()
+		if opts.headTail {
+			head := (opts.maxRows + 1) / 2
+			tail := opts.maxRows - head
+			rows = append(rows[:head:head], rows[nrows-tail:]...)
+			dotsAt = head
+		} else {
+			rows = rows[:opts.maxRows]
+			dotsAt = opts.maxRows
+		}
 	}
 
 	if showDims {
 		str += fmt.Sprintf("[%dx%d] %s\n\n", nrows, ncols, class)
 	}
 
-	for i := 0; i < df.nrows+1; i++ {
-		add := ""
-		if i != 0 {
-			add = strconv.Itoa(i-1) + ":"
-		}
-		records[i] = append([]string{add}, records[i]...)
+	dots := make([]string, ncols+1)
+	for i := 1; i < ncols+1; i++ {
+		dots[i] = "..."
 	}
-	if shortening {
-		dots := make([]string, ncols+1)
-		for i := 1; i < ncols+1; i++ {
-			dots[i] = "..."
+	records := [][]string{append([]string{""}, df.Names()...)}
+	for k, r := range rows {
+		if k == dotsAt {
+			records = append(records, dots)
 		}
+		record := make([]string, ncols+1)
+		record[0] = strconv.Itoa(r) + ":"
+		for j, col := range df.columns {
+			record[j+1] = formatElem(col.Elem(r))
+		}
+		records = append(records, record)
+	}
+	if shortening && dotsAt == len(rows) {
 		records = append(records, dots)
 	}
 	types := df.Types()
@@ -177,9 +198,12 @@ func (df DataFrame) print(
 
 			records[i][j] = strconv.Quote(records[i][j])
 			records[i][j] = records[i][j][1 : len(records[i][j])-1]
+			if j > 0 {
+				records[i][j] = truncateWidth(records[i][j], opts.maxColWidth)
+			}
 
-			if len(records[i][j]) > maxChars[j] {
-				maxChars[j] = utf8.RuneCountInString(records[i][j])
+			if w := displayWidth(records[i][j]); w > maxChars[j] {
+				maxChars[j] = w
 			}
 		}
 	}
@@ -189,11 +213,14 @@ func (df DataFrame) print(
 		maxCharsCum := 0
 		for colnum, m := range maxChars {
 			maxCharsCum += m
-			if maxCharsCum > maxCharsTotal {
+			if maxCharsCum > opts.maxWidth {
 				maxCols = colnum
 				break
 			}
 		}
+		if opts.maxCols > 0 && opts.maxCols+1 < maxCols {
+			maxCols = opts.maxCols + 1
+		}
 		notShowingNames := records[0][maxCols:]
 		notShowingTypes := typesrow[maxCols:]
 		notShowing = make([]string, len(notShowingNames))
@@ -221,8 +248,8 @@ func (df DataFrame) print(
 		cum := 0
 		i := 0
 		for n, ns := range notShowing {
-			cum += len(ns)
-			if cum > maxCharsTotal {
+			cum += displayWidth(ns)
+			if cum > opts.maxWidth {
 				notShownArr = append(notShownArr, notShowing[i:n])
 				cum = 0
 				i = n
@@ -250,16 +277,16 @@ func (df DataFrame) Set(indexes series.Indexes, newvalues DataFrame) DataFrame {
 		return df
 	}
 	if newvalues.Err != nil {
-		return DataFrame{Err: fmt.Errorf("参数存在错误：%v", newvalues.Err)}
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Set", "argument_has_errors").Wrap(newvalues.Err)}
 	}
 	if df.ncols != newvalues.ncols {
-		return DataFrame{Err: fmt.Errorf("列数不同")}
+		return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "Set", "column_count_mismatch")}
 	}
 	columns := make([]series.Series, df.ncols)
 	for i, s := range df.columns {
 		columns[i] = s.Set(indexes, newvalues.columns[i])
 		if columns[i].Err != nil {
-			df = DataFrame{Err: fmt.Errorf("在第%d列设置错误：%v", i, columns[i].Err)}
+			df = DataFrame{Err: series.WrapError("Set", columns[i].Err).WithColumn(s.Name)}
 			return df
 		}
 	}
@@ -296,12 +323,12 @@ func (df DataFrame) Select(indexes SelectIndexes) DataFrame {
 	}
 	idx, err := parseSelectIndexes(df.ncols, indexes, df.Names())
 	if err != nil {
-		return DataFrame{Err: fmt.Errorf("can't select columns: %v", err)}
+		return DataFrame{Err: series.WrapError("Select", err)}
 	}
 	columns := make([]series.Series, len(idx))
 	for k, i := range idx {
 		if i < 0 || i >= df.ncols {
-			return DataFrame{Err: fmt.Errorf("can't select columns: index out of range")}
+			return DataFrame{Err: series.NewError(series.ErrIndexOutOfRange, "Select", "")}
 		}
 		columns[k] = df.columns[i].Copy()
 	}
@@ -329,7 +356,7 @@ func (df DataFrame) Drop(indexes SelectIndexes) DataFrame {
 	}
 	idx, err := parseSelectIndexes(df.ncols, indexes, df.Names())
 	if err != nil {
-		return DataFrame{Err: fmt.Errorf("无法选择列：%v", err)}
+		return DataFrame{Err: series.WrapError("Drop", err)}
 	}
 	var columns []series.Series
 	for k, col := range df.columns {
@@ -359,58 +386,85 @@ const KEY_ERROR = "KEY_ERROR"
 
 // GroupBy 方法按指定的列名对DataFrame进行分组，并返回Groups结构。
 func (df DataFrame) GroupBy(colnames ...string) *Groups {
+	if df.Err != nil {
+		return &Groups{Err: df.Err}
+	}
 	if len(colnames) <= 0 {
-		return nil
+		return &Groups{Err: series.NewError(series.ErrInvalidArgument, "GroupBy", "no_arguments")}
 	}
 	groupDataFrame := make(map[string]DataFrame)
-	groupSeries := make(map[string][]map[string]interface{})
+	groupIndices := make(map[string][]int)
 
 	// 检查列名是否存在于DataFrame中。
 	for _, c := range colnames {
 		if idx := findInStringSlice(c, df.Names()); idx == -1 {
-			return &Groups{Err: fmt.Errorf("GroupBy: 无法找到列名：%s", c)}
+			return &Groups{Err: series.ColumnNotFoundError("GroupBy", c)}
 		}
 	}
 
-	// 按指定的列对DataFrame进行分组。
-	for _, s := range df.Maps() {
-		key := ""
-		for i, c := range colnames {
-			format := ""
-			if i == 0 {
-				format = "%s%"
-			} else {
-				format = "%s_%"
-			}
-			switch s[c].(type) {
-			case string, bool:
-				format += "s"
-			case int, int16, int32, int64:
-				format += "d"
-			case float32, float64:
-				format += "f"
-			default:
-				return &Groups{Err: fmt.Errorf("GroupBy: 未找到类型")}
+	// 计算每行的分组键，多个分组列的键以 "_" 连接。
+	keys := make([]string, df.nrows)
+	for i, c := range colnames {
+		parts, err := groupKeys(df.columns[df.colIndex(c)])
+		if err != nil {
+			return &Groups{Err: err}
+		}
+		for row, part := range parts {
+			if i > 0 {
+				keys[row] += "_"
 			}
-			key = fmt.Sprintf(format, key, s[c])
+			keys[row] += part
 		}
-		groupSeries[key] = append(groupSeries[key], s)
 	}
-
-	// 确定列类型。
-	colTypes := map[string]series.Type{}
-	for _, c := range df.columns {
-		colTypes[c.Name] = c.Type()
+	for row, key := range keys {
+		groupIndices[key] = append(groupIndices[key], row)
 	}
 
-	// 为每个组创建DataFrame。
-	for k, cMaps := range groupSeries {
-		groupDataFrame[k] = LoadMaps(cMaps, WithTypes(colTypes))
+	// 为每个组创建DataFrame，各列保持原来的类型，Categorical 列保留原来的类别。
+	for k, rows := range groupIndices {
+		groupDataFrame[k] = df.Subset(rows)
 	}
-	groups := &Groups{groups: groupDataFrame, colnames: colnames}
+	groups := &Groups{groups: groupDataFrame, colnames: colnames, indices: groupIndices, df: df}
 	return groups
 }
 
+// groupKeys 返回 col 每行的分组键。Categorical 列按编码查找类别，每个类别只格式化一次；
+// 其他列按值的类型格式化，NaN 和不支持的类型返回错误。
+func groupKeys(col series.Series) ([]string, error) {
+	keys := make([]string, col.Len())
+	if col.Type() == series.Categorical {
+		categories := col.Categories()
+		for row, code := range col.Codes() {
+			if code < 0 {
+				return nil, series.NewError(series.ErrUnknownType, "GroupBy", "group_key_type").WithColumn(col.Name)
+			}
+			keys[row] = categories[code]
+		}
+		return keys, nil
+	}
+	_, registered := series.LookupType(col.Type())
+	for row := range keys {
+		v := col.Val(row)
+		format := ""
+		switch v.(type) {
+		case string, bool:
+			format = "%s"
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			format = "%d"
+		case float32, float64:
+			format = "%f"
+		default:
+			// 注册类型的值使用默认格式
+			if !registered {
+				return nil, series.NewError(series.ErrUnknownType, "GroupBy", "group_key_type").WithColumn(col.Name)
+			}
+			format = "%v"
+		}
+		keys[row] = fmt.Sprintf(format, v)
+	}
+	return keys, nil
+}
+
 // AggregationType 定义聚合操作的类型。
 type AggregationType int
 
@@ -424,10 +478,33 @@ const (
 	Aggregation_COUNT
 )
 
+// String 方法返回AggregationType的字符串表示。
+func (a AggregationType) String() string {
+	switch a {
+	case Aggregation_MAX:
+		return "MAX"
+	case Aggregation_MIN:
+		return "MIN"
+	case Aggregation_MEAN:
+		return "MEAN"
+	case Aggregation_MEDIAN:
+		return "MEDIAN"
+	case Aggregation_STD:
+		return "STD"
+	case Aggregation_SUM:
+		return "SUM"
+	case Aggregation_COUNT:
+		return "COUNT"
+	}
+	return fmt.Sprintf("AggregationType(%d)", int(a))
+}
+
 // Groups 表示分组的数据并支持聚合操作。
 type Groups struct {
 	groups      map[string]DataFrame // 分组数据的映射，以分组的名称作为键，对应的值为DataFrame对象
 	colnames    []string             // 列名的切片
+	indices     map[string][]int     // 每个分组在原始DataFrame中的行索引
+	df          DataFrame            // 分组前的原始DataFrame
 	aggregation DataFrame            // 聚合结果的DataFrame对象
 	Err         error                // 错误信息
 }
@@ -436,13 +513,15 @@ type Groups struct {
 // 它返回包含聚合结果的新DataFrame。
 func (gps Groups) Aggregation(typs []AggregationType, colnames []string) DataFrame {
 	if gps.groups == nil {
-		return DataFrame{Err: fmt.Errorf("Aggregation: 输入为nil")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "Aggregation", "nil_input")}
 	}
 	if len(typs) != len(colnames) {
-		return DataFrame{Err: fmt.Errorf("Aggregation: len(typs) != len(colanmes)")}
+		return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "Aggregation", "aggregation_length")}
 	}
 	dfMaps := make([]map[string]interface{}, 0)
-	for _, df := range gps.groups {
+	var firstRows []int
+	for k, df := range gps.groups {
+		firstRows = append(firstRows, gps.indices[k][0])
 		targetMap := df.Maps()[0]
 		curMap := make(map[string]interface{})
 
@@ -450,31 +529,15 @@ func (gps Groups) Aggregation(typs []AggregationType, colnames []string) DataFra
 			if value, ok := targetMap[c]; ok {
 				curMap[c] = value
 			} else {
-				return DataFrame{Err: fmt.Errorf("Aggregation: 无法找到列名：%s", c)}
+				return DataFrame{Err: series.ColumnNotFoundError("Aggregation", c)}
 			}
 		}
 
 		for i, c := range colnames {
 			curSeries := df.Col(c)
-			var value float64
-			switch typs[i] {
-			case Aggregation_MAX:
-				value = curSeries.Max()
-			case Aggregation_MEAN:
-				value = curSeries.Mean()
-			case Aggregation_MEDIAN:
-				value = curSeries.Median()
-			case Aggregation_MIN:
-				value = curSeries.Min()
-			case Aggregation_STD:
-				value = curSeries.StdDev()
-			case Aggregation_SUM:
-				value = curSeries.Sum()
-			case Aggregation_COUNT:
-				value = float64(curSeries.Len())
-			default:
-				return DataFrame{Err: fmt.Errorf("Aggregation: 未找到该方法：%s", typs[i])}
-
+			value, err := aggregate(curSeries, typs[i])
+			if err != nil {
+				return DataFrame{Err: series.WrapError("Aggregation", err).WithColumn(c)}
 			}
 			curMap[fmt.Sprintf("%s_%s", c, typs[i])] = value
 		}
@@ -495,11 +558,45 @@ func (gps Groups) Aggregation(typs []AggregationType, colnames []string) DataFra
 			continue
 		}
 	}
+	// 注册类型和整数类型的分组列保持原来的类型
+	for _, c := range gps.colnames {
+		t := gps.df.Col(c).Type()
+		if _, ok := series.LookupType(t); ok || series.IsInteger(t) {
+			colTypes[c] = t
+		}
+	}
 
 	gps.aggregation = LoadMaps(dfMaps, WithTypes(colTypes))
+	// Categorical 分组列取自每组的第一行，保留原来的类别及其顺序
+	for _, c := range gps.colnames {
+		if col := gps.df.Col(c); col.Type() == series.Categorical {
+			gps.aggregation = gps.aggregation.Mutate(col.Subset(firstRows))
+		}
+	}
 	return gps.aggregation
 }
 
+// aggregate 按照给定的AggregationType计算Series的聚合值。
+func aggregate(s series.Series, typ AggregationType) (float64, error) {
+	switch typ {
+	case Aggregation_MAX:
+		return s.Max(), nil
+	case Aggregation_MEAN:
+		return s.Mean(), nil
+	case Aggregation_MEDIAN:
+		return s.Median(), nil
+	case Aggregation_MIN:
+		return s.Min(), nil
+	case Aggregation_STD:
+		return s.StdDev(), nil
+	case Aggregation_SUM:
+		return s.Sum(), nil
+	case Aggregation_COUNT:
+		return float64(s.Len()), nil
+	}
+	return 0, series.NewError(series.ErrInvalidArgument, "", "unknown_aggregation", typ)
+}
+
 // GetGroups 方法返回Groups中的分组数据。
 func (g Groups) GetGroups() map[string]DataFrame {
 	return g.groups
@@ -515,7 +612,7 @@ func (df DataFrame) Rename(newname, oldname string) DataFrame {
 	colnames := df.Names()
 	idx := findInStringSlice(oldname, colnames)
 	if idx == -1 {
-		return DataFrame{Err: fmt.Errorf("rename: 无法找到列名")}
+		return DataFrame{Err: series.ColumnNotFoundError("Rename", oldname)}
 	}
 
 	copy := df.Copy()
@@ -549,14 +646,14 @@ func (df DataFrame) RBind(dfb DataFrame) DataFrame {
 	for k, v := range df.Names() {
 		idx := findInStringSlice(v, dfb.Names())
 		if idx == -1 {
-			return DataFrame{Err: fmt.Errorf("rbind: 列名不兼容")}
+			return DataFrame{Err: series.NewError(series.ErrColumnNotFound, "RBind", "incompatible_columns").WithColumn(v)}
 		}
 
 		originalSeries := df.columns[k]
 		addedSeries := dfb.columns[idx]
 		newSeries := originalSeries.Concat(addedSeries)
 		if err := newSeries.Err; err != nil {
-			return DataFrame{Err: fmt.Errorf("rbind: %v", err)}
+			return DataFrame{Err: series.WrapError("RBind", err).WithColumn(v)}
 		}
 		expandedSeries[k] = newSeries
 	}
@@ -594,16 +691,16 @@ func (df DataFrame) Concat(dfb DataFrame) DataFrame {
 			a = df.columns[aidx]
 		} else {
 			bb := dfb.columns[bidx]
-			a = series.New(make([]struct{}, df.nrows), bb.Type(), bb.Name)
+			a = series.New(make([]interface{}, df.nrows), bb.Type(), bb.Name)
 		}
 		if bidx != -1 {
 			b = dfb.columns[bidx]
 		} else {
-			b = series.New(make([]struct{}, dfb.nrows), a.Type(), a.Name)
+			b = series.New(make([]interface{}, dfb.nrows), a.Type(), a.Name)
 		}
 		newSeries := a.Concat(b)
 		if err := newSeries.Err; err != nil {
-			return DataFrame{Err: fmt.Errorf("concat: %v", err)}
+			return DataFrame{Err: series.WrapError("Concat", err).WithColumn(v)}
 		}
 		expandedSeries[k] = newSeries
 	}
@@ -617,7 +714,7 @@ func (df DataFrame) Mutate(s series.Series) DataFrame {
 		return df
 	}
 	if s.Len() != df.nrows {
-		return DataFrame{Err: fmt.Errorf("mutate: 维度不匹配")}
+		return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "Mutate", "").WithColumn(s.Name)}
 	}
 	df = df.Copy()
 
@@ -668,7 +765,7 @@ func (a Aggregation) String() string {
 	case And:
 		return "and"
 	}
-	return fmt.Sprintf("未知的聚合类型 %d", a)
+	return series.Message("unknown_filter_agg", int(a))
 }
 
 // 定义Aggregation常量。
@@ -682,21 +779,27 @@ func (df DataFrame) FilterAggregation(agg Aggregation, filters ...F) DataFrame {
 	if df.Err != nil {
 		return df
 	}
+	if agg != Or && agg != And {
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Filter", "unknown_filter_agg", int(agg))}
+	}
 
 	compResults := make([]series.Series, len(filters))
 	for i, f := range filters {
 		var idx int
 		if f.Colname == "" {
 			idx = f.Colidx
+			if idx < 0 || idx >= df.ncols {
+				return DataFrame{Err: series.NewError(series.ErrIndexOutOfRange, "Filter", "")}
+			}
 		} else {
 			idx = findInStringSlice(f.Colname, df.Names())
 			if idx < 0 {
-				return DataFrame{Err: fmt.Errorf("filter: 无法找到列名")}
+				return DataFrame{Err: series.ColumnNotFoundError("Filter", f.Colname)}
 			}
 		}
 		res := df.columns[idx].Compare(f.Comparator, f.Comparando)
 		if err := res.Err; err != nil {
-			return DataFrame{Err: fmt.Errorf("filter: %v", err)}
+			return DataFrame{Err: series.WrapError("Filter", err)}
 		}
 		compResults[i] = res
 	}
@@ -707,12 +810,12 @@ func (df DataFrame) FilterAggregation(agg Aggregation, filters ...F) DataFrame {
 
 	res, err := compResults[0].Bool()
 	if err != nil {
-		return DataFrame{Err: fmt.Errorf("filter: %v", err)}
+		return DataFrame{Err: series.WrapError("Filter", err)}
 	}
 	for i := 1; i < len(compResults); i++ {
 		nextRes, err := compResults[i].Bool()
 		if err != nil {
-			return DataFrame{Err: fmt.Errorf("filter: %v", err)}
+			return DataFrame{Err: series.WrapError("Filter", err)}
 		}
 		for j := 0; j < len(res); j++ {
 			switch agg {
@@ -720,28 +823,35 @@ func (df DataFrame) FilterAggregation(agg Aggregation, filters ...F) DataFrame {
 				res[j] = res[j] || nextRes[j]
 			case And:
 				res[j] = res[j] && nextRes[j]
-			default:
-				panic(agg)
 			}
 		}
 	}
 	return df.Subset(res)
 }
 
-// Order 结构表示排序的参数，包括列名和是否降序。
+// Order 结构表示排序的参数，包括列名、是否降序以及字符串列的排序规则。
 type Order struct {
-	Colname string
-	Reverse bool
+	Colname   string
+	Reverse   bool
+	Collation series.Collation // 为 nil 时按字节顺序排序
+}
+
+// Sort 函数返回一个升序排序的Order结构，可以通过 collation 指定字符串列的排序规则。
+func Sort(colname string, collation ...series.Collation) Order {
+	return Order{Colname: colname, Reverse: false, Collation: firstCollation(collation)}
 }
 
-// Sort 函数返回一个升序排序的Order结构。
-func Sort(colname string) Order {
-	return Order{colname, false}
+// RevSort 函数返回一个降序排序的Order结构，可以通过 collation 指定字符串列的排序规则。
+func RevSort(colname string, collation ...series.Collation) Order {
+	return Order{Colname: colname, Reverse: true, Collation: firstCollation(collation)}
 }
 
-// RevSort 函数返回一个降序排序的Order结构。
-func RevSort(colname string) Order {
-	return Order{colname, true}
+// firstCollation 返回可选参数中的第一个排序规则，没有时返回 nil。
+func firstCollation(collation []series.Collation) series.Collation {
+	if len(collation) == 0 {
+		return nil
+	}
+	return collation[0]
 }
 
 // Arrange 方法按照指定的排序参数对DataFrame进行排序。
@@ -750,14 +860,23 @@ func (df DataFrame) Arrange(order ...Order) DataFrame {
 	if df.Err != nil {
 		return df
 	}
+	origIdx, err := df.order(order...)
+	if err != nil {
+		return DataFrame{Err: err}
+	}
+	return df.Subset(origIdx)
+}
+
+// order 返回按照指定排序参数排列DataFrame各行所需的行索引。
+func (df DataFrame) order(order ...Order) ([]int, error) {
 	if order == nil || len(order) == 0 {
-		return DataFrame{Err: fmt.Errorf("rename: 无参数")}
+		return nil, series.NewError(series.ErrInvalidArgument, "Arrange", "no_arguments")
 	}
 
 	for i := 0; i < len(order); i++ {
 		colname := order[i].Colname
 		if df.colIndex(colname) == -1 {
-			return DataFrame{Err: fmt.Errorf("colname %s 不存在", colname)}
+			return nil, series.ColumnNotFoundError("Arrange", colname)
 		}
 	}
 
@@ -779,10 +898,10 @@ func (df DataFrame) Arrange(order ...Order) DataFrame {
 		colname := order[i].Colname
 		idx := df.colIndex(colname)
 		nextSeries := df.columns[idx].Subset(suborder)
-		suborder = nextSeries.Order(order[i].Reverse)
+		suborder = nextSeries.Order(order[i].Reverse, order[i].Collation)
 		swapOrigIdx(suborder)
 	}
-	return df.Subset(origIdx)
+	return origIdx, nil
 }
 
 // Capply 方法对DataFrame的每一列应用给定的函数。
@@ -809,12 +928,12 @@ func (df DataFrame) Rapply(f func(series.Series) series.Series) DataFrame {
 	}
 
 	// 辅助函数，用于检测一组序列类型中的共同类型。
-	detectType := func(types []series.Type) series.Type {
+	detectType := func(types []series.Type) (series.Type, error) {
 		var hasStrings, hasFloats, hasInts, hasBools bool
 		// 遍历类型并根据每种类型的存在情况设置标志。
 		for _, t := range types {
 			switch t {
-			case series.String:
+			case series.String, series.Categorical:
 				hasStrings = true
 			case series.Float:
 				hasFloats = true
@@ -822,28 +941,33 @@ func (df DataFrame) Rapply(f func(series.Series) series.Series) DataFrame {
 				hasInts = true
 			case series.Bool:
 				hasBools = true
+			default:
+				hasInts = hasInts || series.IsInteger(t)
 			}
 		}
 		// 根据检测到的标志返回共同的类型。
 		switch {
 		case hasStrings:
-			return series.String
+			return series.String, nil
 		case hasBools:
-			return series.Bool
+			return series.Bool, nil
 		case hasFloats:
-			return series.Float
+			return series.Float, nil
 		case hasInts:
-			return series.Int
+			return series.Int, nil
 		default:
-			// 如果没有找到支持的类型，则引发错误。
-			panic("不支持的类型")
+			// 如果没有找到支持的类型，则返回错误。
+			return "", series.NewError(series.ErrUnknownType, "Rapply", "type_detection")
 		}
 	}
 
 	// 获取DataFrame中列的类型。
 	types := df.Types()
 	// 确定行的共同类型。
-	rowType := detectType(types)
+	rowType, err := detectType(types)
+	if err != nil {
+		return DataFrame{Err: err}
+	}
 
 	// 初始化二维数组以存储转换后的元素。
 	elements := make([][]series.Element, df.nrows)
@@ -862,12 +986,12 @@ func (df DataFrame) Rapply(f func(series.Series) series.Series) DataFrame {
 		row = f(row)
 		// 检查应用函数时是否发生错误。
 		if row.Err != nil {
-			return DataFrame{Err: fmt.Errorf("在行 %d 上应用函数时发生错误: %v", i, row.Err)}
+			return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Rapply", "row_apply", i).Wrap(row.Err)}
 		}
 
 		// 检查行长度是否一致。
 		if rowlen != -1 && rowlen != row.Len() {
-			return DataFrame{Err: fmt.Errorf("应用函数时发生错误: 行具有不同的长度")}
+			return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "Rapply", "row_lengths")}
 		}
 		rowlen = row.Len()
 
@@ -879,6 +1003,11 @@ func (df DataFrame) Rapply(f func(series.Series) series.Series) DataFrame {
 		elements[i] = rowElems
 	}
 
+	// 没有任何行时无法确定结果的列数。
+	if rowlen < 0 {
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "Rapply", "empty_dataframe")}
+	}
+
 	// 初始化数组以存储转换后的列。
 	columns := make([]series.Series, rowlen)
 	// 遍历每一列。
@@ -889,7 +1018,10 @@ func (df DataFrame) Rapply(f func(series.Series) series.Series) DataFrame {
 			types[i] = elements[i][j].Type()
 		}
 		// 确定列的共同类型。
-		colType := detectType(types)
+		colType, err := detectType(types)
+		if err != nil {
+			return DataFrame{Err: err}
+		}
 		// 创建一个具有共同类型的新空列序列。
 		s := series.New(nil, colType, "").Empty()
 		// 将每行的元素附加到列序列。
@@ -938,6 +1070,24 @@ type loadOptions struct {
 	lazyQuotes  bool                   // 懒惰引号模式
 	comment     rune                   // 注释符号
 	types       map[string]series.Type // 系列类型映射表
+	skipRows    int                    // 跳过开头的记录数
+	nrows       int                    // 最多读取的数据行数，0 表示不限制
+	useColumns  SelectIndexes          // 需要读取的列
+	thousands   rune                   // 数字的千位分隔符
+	decimal     rune                   // 数字的小数点
+	trueValues  []string               // 表示 true 的字符串
+	falseValues []string               // 表示 false 的字符串
+	trimSpace   bool                   // 是否去除字段首尾的空白字符
+	converters  map[string]Converter   // 列的自定义转换函数
+	ragged      bool                   // 是否容忍字段数不一致的行
+	raggedRows  *[]RowError            // 字段数不一致的行的报告
+	encoding    string                 // 输入的字符编码
+	orient      Orient                 // JSON 输入的结构
+	recordPath  string                 // JSONNormalize 展开为行的记录路径
+	metaFields  []string               // JSONNormalize 附加到每一行的上层字段
+	metaPrefix  string                 // JSONNormalize 附加列的列名前缀
+	maxDepth    int                    // JSONNormalize 展平嵌套对象的最大层数
+	columnOrder []string               // LoadMaps 的列顺序
 }
 
 // DefaultType 函数返回一个LoadOption，用于设置默认列类型。
@@ -968,6 +1118,14 @@ func Names(names ...string) LoadOption {
 	}
 }
 
+// ColumnOrder 函数返回一个LoadOption，用于设置 LoadMaps 的列顺序。names 中的列按照给定的顺序排在最前，
+// 其余的列按照列名排序排在之后；没有出现在任何 map 中的列与缺少的键一样加载。
+func ColumnOrder(names ...string) LoadOption {
+	return func(c *loadOptions) {
+		c.columnOrder = names
+	}
+}
+
 // NaNValues 函数返回一个LoadOption，用于设置NaN值的字符串表示。
 func NaNValues(nanValues []string) LoadOption {
 	return func(c *loadOptions) {
@@ -1003,11 +1161,90 @@ func WithComments(b rune) LoadOption {
 	}
 }
 
-// LoadStructs 函数从给定的切片中加载结构体数据，并返回一个DataFrame。
-// 可以使用LoadOption配置加载过程。
+// SkipRows 函数返回一个LoadOption，用于跳过表头之前的 n 条记录。
+func SkipRows(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.skipRows = n
+	}
+}
+
+// NRows 函数返回一个LoadOption，用于设置最多读取的数据行数，n <= 0 表示不限制。
+func NRows(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.nrows = n
+	}
+}
+
+// UseColumns 函数返回一个LoadOption，用于设置需要读取的列。indexes 支持与 Select 相同的类型，
+// 可以按列名或列索引选择，结果中的列按 indexes 的顺序排列。
+func UseColumns(indexes SelectIndexes) LoadOption {
+	return func(c *loadOptions) {
+		c.useColumns = indexes
+	}
+}
+
+// Thousands 函数返回一个LoadOption，用于设置数字的千位分隔符，例如 "1,234,567" 使用 ','。
+// 只有去除分隔符后能解析为数字的列才会被当作数字处理。
+func Thousands(sep rune) LoadOption {
+	return func(c *loadOptions) {
+		c.thousands = sep
+	}
+}
+
+// DecimalComma 函数返回一个LoadOption，用于设置是否使用逗号作为小数点，例如 "3,14"。
+// 通常需要同时使用 Thousands('.') 和 WithDelimiter(';')。
+func DecimalComma(b bool) LoadOption {
+	return func(c *loadOptions) {
+		if b {
+			c.decimal = ','
+		} else {
+			c.decimal = 0
+		}
+	}
+}
+
+// BoolValues 函数返回一个LoadOption，用于设置表示 true 和 false 的字符串，例如 "yes"/"no" 或 "是"/"否"。
+func BoolValues(trueValues, falseValues []string) LoadOption {
+	return func(c *loadOptions) {
+		c.trueValues = trueValues
+		c.falseValues = falseValues
+	}
+}
+
+// TrimSpace 函数返回一个LoadOption，用于设置是否去除字段首尾的空白字符。
+func TrimSpace(b bool) LoadOption {
+	return func(c *loadOptions) {
+		c.trimSpace = b
+	}
+}
+
+// WithConverters 函数返回一个LoadOption，用于设置列的自定义转换函数，键为列名。
+// 转换函数的结果决定列的类型，也可以通过 WithTypes 指定。
+func WithConverters(converters map[string]Converter) LoadOption {
+	return func(c *loadOptions) {
+		c.converters = converters
+	}
+}
+
+// AllowRaggedRows 函数返回一个LoadOption，用于容忍字段数与表头不一致的行：缺少的字段为 NaN，
+// 多余的字段被丢弃。每个被修正的行都会记录到 report 中，report 为 nil 时不记录。
+func AllowRaggedRows(report *[]RowError) LoadOption {
+	return func(c *loadOptions) {
+		c.ragged = true
+		c.raggedRows = report
+	}
+}
+
+// LoadStructs 函数从给定的切片中加载结构体数据，并返回一个DataFrame。切片的元素可以是结构体或结构体指针，
+// nil 指针元素对应全部为 NaN 的一行。可以使用LoadOption配置加载过程。
+//
+// 每个导出的字段对应一列，列名和类型可以通过 `dataframe:"列名,类型"` 标签指定，标签为 "-" 的字段被忽略。
+// 嵌入的结构体的字段直接展开，其他嵌套的结构体以 "字段名." 为前缀展开。nil 指针字段、无效的 sql.Null*
+// 值和 NaNValues 中的字符串加载为 NaN。time.Time 和实现了 Marshaler 或 encoding.TextMarshaler 的类型
+// 加载为字符串，实现了 driver.Valuer 的类型（例如 sql.NullInt64）使用 Value 返回的值。
 func LoadStructs(i interface{}, options ...LoadOption) DataFrame {
 	if i == nil {
-		return DataFrame{Err: fmt.Errorf("load: 无法从 <nil> 值创建DataFrame")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "LoadStructs", "load_nil")}
 	}
 
 	cfg := loadOptions{
@@ -1022,100 +1259,96 @@ func LoadStructs(i interface{}, options ...LoadOption) DataFrame {
 	}
 
 	tpy, val := reflect.TypeOf(i), reflect.ValueOf(i)
-	switch tpy.Kind() {
-	case reflect.Slice:
-		if tpy.Elem().Kind() != reflect.Struct {
-			return DataFrame{Err: fmt.Errorf(
-				"load: 类型 %s (%s %s) 不受支持，必须是 []struct", tpy.Name(), tpy.Elem().Kind(), tpy.Kind())}
-		}
-		if val.Len() == 0 {
-			return DataFrame{Err: fmt.Errorf("load: 无法从空切片创建DataFrame")}
-		}
-
-		numFields := val.Index(0).Type().NumField()
-		var columns []series.Series
-		for j := 0; j < numFields; j++ {
-
-			if !val.Index(0).Field(j).CanInterface() {
-				continue
-			}
-			field := val.Index(0).Type().Field(j)
-			fieldName := field.Name
-			fieldType := field.Type.String()
+	if tpy.Kind() != reflect.Slice {
+		return DataFrame{Err: series.NewError(series.ErrUnknownType, "LoadStructs", "load_type", tpy.Name(), tpy.Kind())}
+	}
+	structType := tpy.Elem()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return DataFrame{Err: series.NewError(series.ErrUnknownType, "LoadStructs", "load_type", tpy.Name(), tpy.Elem().Kind().String()+" "+tpy.Kind().String())}
+	}
+	if val.Len() == 0 {
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "LoadStructs", "load_empty_slice")}
+	}
 
-			fieldTags := field.Tag.Get("dataframe")
-			if fieldTags == "-" {
+	fields, err := structColumns(structType)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("LoadStructs", err)}
+	}
+	var columns []series.Series
+	for _, field := range fields {
+		fieldName := field.name
+		elements := make([]interface{}, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			fv, ok := fieldByIndex(val.Index(i), field.index)
+			if !ok {
 				continue
 			}
-			tagOpts := strings.Split(fieldTags, ",")
-			if len(tagOpts) > 2 {
-				return DataFrame{Err: fmt.Errorf("字段 %s 上的结构体标签格式错误: %s", fieldName, fieldTags)}
-			}
-			if len(tagOpts) > 0 {
-				if name := strings.TrimSpace(tagOpts[0]); name != "" {
-					fieldName = name
-				}
-				if len(tagOpts) == 2 {
-					if tagType := strings.TrimSpace(tagOpts[1]); tagType != "" {
-						fieldType = tagType
-					}
-				}
+			v, err := structValue(fv)
+			if err != nil {
+				return DataFrame{Err: columnError("LoadStructs", fieldName, err)}
 			}
-
-			var t series.Type
-			if cfgtype, ok := cfg.types[fieldName]; ok {
-				t = cfgtype
-			} else {
-
-				if cfg.detectTypes {
-
-					parsedType, err := parseType(fieldType)
-					if err != nil {
-						return DataFrame{Err: err}
-					}
-					t = parsedType
-				} else {
-					t = cfg.defaultType
-				}
+			if s, ok := v.(string); ok && findInStringSlice(s, cfg.nanValues) != -1 {
+				v = nil
 			}
+			elements[i] = v
+		}
 
-			elements := make([]interface{}, val.Len())
-			for i := 0; i < val.Len(); i++ {
-				fieldValue := val.Index(i).Field(j)
-				elements[i] = fieldValue.Interface()
-
-				if findInStringSlice(fmt.Sprint(elements[i]), cfg.nanValues) != -1 {
-					elements[i] = nil
+		t, ok := cfg.types[fieldName]
+		if !ok {
+			if cfg.detectTypes {
+				t, err = field.columnType()
+				if err != nil {
+					return DataFrame{Err: columnError("LoadStructs", fieldName, err)}
 				}
+			} else {
+				t = cfg.defaultType
 			}
+		}
 
-			if !cfg.hasHeader {
-				tmp := make([]interface{}, 1)
-				tmp[0] = fieldName
-				elements = append(tmp, elements...)
-				fieldName = ""
-			}
+		if !cfg.hasHeader {
+			tmp := make([]interface{}, 1)
+			tmp[0] = fieldName
+			elements = append(tmp, elements...)
+			fieldName = ""
+		}
+		if t == "" {
+			columns = append(columns, nativeColumn(elements, fieldName, cfg))
+		} else {
 			columns = append(columns, series.New(elements, t, fieldName))
 		}
-		return New(columns...)
 	}
-	return DataFrame{Err: fmt.Errorf(
-		"load: 类型 %s (%s) 不受支持，必须是 []struct", tpy.Name(), tpy.Kind())}
+	return New(columns...)
 }
 
-// parseType 将字符串类型映射为 series.Type。
+// parseType 将字符串类型映射为 series.Type，Go 的整数类型名称映射为位数相同的整数类型，uint 映射为 Uint64。Decimal 类型可以写为 "decimal" 或 "decimal(10,2)"，
+// 通过 series.RegisterType 注册的类型使用其名称。
 func parseType(s string) (series.Type, error) {
 	switch s {
 	case "float", "float64", "float32":
 		return series.Float, nil
-	case "int", "int64", "int32", "int16", "int8":
+	case "int":
 		return series.Int, nil
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return series.Type(s), nil
+	case "uint":
+		return series.Uint64, nil
 	case "string":
 		return series.String, nil
 	case "bool":
 		return series.Bool, nil
+	case "categorical", "category":
+		return series.Categorical, nil
 	}
-	return "", fmt.Errorf("类型 (%s) 不受支持", s)
+	if t := series.Type(s); series.IsDecimal(t) {
+		return t, nil
+	}
+	if _, ok := series.LookupType(series.Type(s)); ok {
+		return series.Type(s), nil
+	}
+	return "", series.NewError(series.ErrUnknownType, "", "unsupported_type", s)
 }
 
 // LoadRecords 从字符串切片记录加载 DataFrame。
@@ -1131,17 +1364,24 @@ func LoadRecords(records [][]string, options ...LoadOption) DataFrame {
 		option(&cfg)
 	}
 
+	if cfg.skipRows > 0 {
+		if cfg.skipRows >= len(records) {
+			records = nil
+		} else {
+			records = records[cfg.skipRows:]
+		}
+	}
 	if len(records) == 0 {
-		return DataFrame{Err: fmt.Errorf("load records: 空 DataFrame")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "LoadRecords", "empty_dataframe")}
 	}
 	if cfg.hasHeader && len(records) <= 1 {
-		return DataFrame{Err: fmt.Errorf("load records: 空 DataFrame")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "LoadRecords", "empty_dataframe")}
 	}
-	if cfg.names != nil && len(cfg.names) != len(records[0]) {
+	if cfg.names != nil && !cfg.ragged && len(cfg.names) != len(records[0]) {
 		if len(cfg.names) > len(records[0]) {
-			return DataFrame{Err: fmt.Errorf("load records: 列名过多")}
+			return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "LoadRecords", "too_many_names")}
 		}
-		return DataFrame{Err: fmt.Errorf("load records: 列名不足")}
+		return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "LoadRecords", "too_few_names")}
 	}
 
 	headers := make([]string, len(records[0]))
@@ -1152,38 +1392,59 @@ func LoadRecords(records [][]string, options ...LoadOption) DataFrame {
 	if cfg.names != nil {
 		headers = cfg.names
 	}
+	if cfg.trimSpace {
+		trimmed := make([]string, len(headers))
+		for i, h := range headers {
+			trimmed[i] = strings.TrimSpace(h)
+		}
+		headers = trimmed
+	}
+	if cfg.nrows > 0 && len(records) > cfg.nrows {
+		records = records[:cfg.nrows]
+	}
+	records, err := fixRaggedRows(records, len(headers), cfg)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("LoadRecords", err)}
+	}
+
+	usecols := make([]int, len(headers))
+	for i := range usecols {
+		usecols[i] = i
+	}
+	if cfg.useColumns != nil {
+		usecols, err = parseSelectIndexes(len(headers), cfg.useColumns, headers)
+		if err != nil {
+			return DataFrame{Err: series.WrapError("LoadRecords", err)}
+		}
+		for _, i := range usecols {
+			if i < 0 || i >= len(headers) {
+				return DataFrame{Err: series.NewError(series.ErrIndexOutOfRange, "LoadRecords", "")}
+			}
+		}
+	}
 
-	types := make([]series.Type, len(headers))
-	rawcols := make([][]string, len(headers))
-	for i, colname := range headers {
+	columns := make([]series.Series, len(usecols))
+	for k, i := range usecols {
+		colname := headers[i]
 		rawcol := make([]string, len(records))
 		for j := 0; j < len(records); j++ {
 			rawcol[j] = records[j][i]
+			if cfg.trimSpace {
+				rawcol[j] = strings.TrimSpace(rawcol[j])
+			}
 			if findInStringSlice(rawcol[j], cfg.nanValues) != -1 {
 				rawcol[j] = "NaN"
 			}
 		}
-		rawcols[i] = rawcol
 
-		t, ok := cfg.types[colname]
-		if !ok {
-			t = cfg.defaultType
-			if cfg.detectTypes {
-				if l, err := findType(rawcol); err == nil {
-					t = l
-				}
-			}
+		if convert, ok := cfg.converters[colname]; ok {
+			columns[k] = convertColumn(rawcol, convert, colname, cfg)
+		} else {
+			columns[k] = parseColumn(rawcol, colname, cfg)
 		}
-		types[i] = t
-	}
-
-	columns := make([]series.Series, len(headers))
-	for i, colname := range headers {
-		col := series.New(rawcols[i], types[i], colname)
-		if col.Err != nil {
-			return DataFrame{Err: col.Err}
+		if columns[k].Err != nil {
+			return DataFrame{Err: columnError("LoadRecords", colname, columns[k].Err)}
 		}
-		columns[i] = col
 	}
 	nrows, ncols, err := checkColumnsDimensions(columns...)
 	if err != nil {
@@ -1203,10 +1464,12 @@ func LoadRecords(records [][]string, options ...LoadOption) DataFrame {
 	return df
 }
 
-// LoadMaps 从 map 数组加载 DataFrame。
+// LoadMaps 从 map 数组加载 DataFrame，值为 nil 的元素加载为 NaN。Go 的 map 没有键的顺序，
+// 列默认按照列名排序，可以使用 ColumnOrder 指定列的顺序。只包含整数和浮点数或只包含布尔值的列
+// 直接使用 map 中的值创建，不经过字符串转换；time.Time 值加载为 RFC 3339 格式的字符串。
 func LoadMaps(maps []map[string]interface{}, options ...LoadOption) DataFrame {
 	if len(maps) == 0 {
-		return DataFrame{Err: fmt.Errorf("load maps: 空数组")}
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "LoadMaps", "empty_array")}
 	}
 	inStrSlice := func(i string, s []string) bool {
 		for _, v := range s {
@@ -1226,21 +1489,41 @@ func LoadMaps(maps []map[string]interface{}, options ...LoadOption) DataFrame {
 		}
 	}
 	sort.Strings(colnames)
-	records := make([][]string, len(maps)+1)
-	records[0] = colnames
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.columnOrder != nil {
+		colnames = orderColumns(colnames, cfg.columnOrder)
+	}
+	rows := make([][]interface{}, len(maps))
 	for k, m := range maps {
-		row := make([]string, len(colnames))
+		row := make([]interface{}, len(colnames))
 		for i, colname := range colnames {
-			element := ""
 			val, ok := m[colname]
-			if ok {
-				element = fmt.Sprint(val)
+			if !ok {
+				val = missingValue{}
 			}
-			row[i] = element
+			row[i] = val
 		}
-		records[k+1] = row
+		rows[k] = row
 	}
-	return LoadRecords(records, options...)
+	df := loadRows(colnames, rows, options...)
+	if df.Err != nil {
+		df.Err = series.WrapError("LoadMaps", df.Err)
+	}
+	return df
+}
+
+// orderColumns 返回按照 order 排列的列名，order 中没有的列按原来的顺序排在之后。
+func orderColumns(colnames, order []string) []string {
+	ordered := append([]string(nil), order...)
+	for _, colname := range colnames {
+		if findInStringSlice(colname, order) == -1 {
+			ordered = append(ordered, colname)
+		}
+	}
+	return ordered
 }
 
 type Matrix interface {
@@ -1278,37 +1561,72 @@ func LoadMatrix(mat Matrix) DataFrame {
 
 // ReadCSV 从 CSV 格式的输入读取 DataFrame。
 func ReadCSV(r io.Reader, options ...LoadOption) DataFrame {
-	csvReader := csv.NewReader(r)
 	cfg := loadOptions{
 		delimiter:  ',',
 		lazyQuotes: false,
 		comment:    0,
+		hasHeader:  true,
 	}
 	for _, option := range options {
 		option(&cfg)
 	}
 
+	r, closer, err := openInput(r, cfg.encoding)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("ReadCSV", err)}
+	}
+	defer closer.Close()
+	csvReader := csv.NewReader(r)
 	csvReader.Comma = cfg.delimiter
 	csvReader.LazyQuotes = cfg.lazyQuotes
 	csvReader.Comment = cfg.comment
+	if cfg.ragged {
+		csvReader.FieldsPerRecord = -1
+	}
 
-	records, err := csvReader.ReadAll()
-	if err != nil {
-		return DataFrame{Err: err}
+	// 设置了 NRows 时只读取需要的记录
+	limit := -1
+	if cfg.nrows > 0 {
+		limit = cfg.skipRows + cfg.nrows
+		if cfg.hasHeader {
+			limit++
+		}
+	}
+	var records [][]string
+	for limit < 0 || len(records) < limit {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return DataFrame{Err: series.WrapError("ReadCSV", err)}
+		}
+		records = append(records, record)
 	}
 	return LoadRecords(records, options...)
 }
 
 // ReadJSON 从 JSON 格式的输入读取 DataFrame。
 func ReadJSON(r io.Reader, options ...LoadOption) DataFrame {
-	var m []map[string]interface{}
-	d := json.NewDecoder(r)
-	d.UseNumber()
-	err := d.Decode(&m)
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	r, closer, err := openInput(r, cfg.encoding)
 	if err != nil {
-		return DataFrame{Err: err}
+		return DataFrame{Err: series.WrapError("ReadJSON", err)}
+	}
+	defer closer.Close()
+
+	colnames, rows, err := parseJSON(json.NewDecoder(r), cfg.orient)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("ReadJSON", err)}
+	}
+	df := loadRows(colnames, rows, options...)
+	if df.Err != nil {
+		df.Err = series.WrapError("ReadJSON", df.Err)
 	}
-	return LoadMaps(m, options...)
+	return df
 }
 
 // WriteOption 定义写操作的选项类型。
@@ -1316,7 +1634,13 @@ type WriteOption func(*writeOptions)
 
 // writeOptions 包含写操作的选项。
 type writeOptions struct {
-	writeHeader bool
+	writeHeader    bool
+	encoding       string      // 输出的字符编码
+	bom            bool        // 是否写入 BOM
+	compression    Compression // 输出的压缩格式
+	compressionSet bool        // 是否设置了压缩格式
+	orient         Orient      // JSON 输出的结构
+	floatPrecision int         // JSON 输出中 Float 列的小数位数，负数表示最短格式
 }
 
 // WriteHeader 指定是否写入 CSV 或 JSON 文件的列头。
@@ -1345,15 +1669,44 @@ func (df DataFrame) WriteCSV(w io.Writer, options ...WriteOption) error {
 		records = records[1:]
 	}
 
-	return csv.NewWriter(w).WriteAll(records)
+	ow, err := openOutput(w, cfg)
+	if err != nil {
+		return series.WrapError("WriteCSV", err)
+	}
+	if err := csv.NewWriter(ow).WriteAll(records); err != nil {
+		ow.Close()
+		return err
+	}
+	return ow.Close()
 }
 
-// WriteJSON 将 DataFrame 写入 JSON 格式。
-func (df DataFrame) WriteJSON(w io.Writer) error {
+// WriteJSON 将 DataFrame 写入 JSON 格式，对象的键按照列的顺序输出，NaN 输出为 null。
+// 支持 WriteOrient、WriteFloatPrecision、WriteEncoding、WriteBOM 和 WriteCompression 选项。
+func (df DataFrame) WriteJSON(w io.Writer, options ...WriteOption) error {
 	if df.Err != nil {
 		return df.Err
 	}
-	return json.NewEncoder(w).Encode(df.Maps())
+
+	cfg := writeOptions{
+		floatPrecision: -1,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	doc, err := df.jsonDocument(cfg)
+	if err != nil {
+		return series.WrapError("WriteJSON", err)
+	}
+	ow, err := openOutput(w, cfg)
+	if err != nil {
+		return series.WrapError("WriteJSON", err)
+	}
+	if err := json.NewEncoder(ow).Encode(doc); err != nil {
+		ow.Close()
+		return err
+	}
+	return ow.Close()
 }
 
 // remainder 包含 HTML 表格中的元素索引、文本和行数。
@@ -1449,6 +1802,16 @@ func ReadHTML(r io.Reader, options ...LoadOption) []DataFrame {
 	var doc *html.Node
 	var f func(*html.Node)
 
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	r, closer, err := openInput(r, cfg.encoding)
+	if err != nil {
+		return []DataFrame{DataFrame{Err: series.WrapError("ReadHTML", err)}}
+	}
+	defer closer.Close()
+
 	doc, err = html.Parse(r)
 	if err != nil {
 		return []DataFrame{DataFrame{Err: err}}
@@ -1507,7 +1870,7 @@ func (df DataFrame) SetNames(colnames ...string) error {
 		return df.Err
 	}
 	if len(colnames) != df.ncols {
-		return fmt.Errorf("设置列名: 维度不匹配")
+		return series.NewError(series.ErrDimensionMismatch, "SetNames", "")
 	}
 	for k, s := range colnames {
 		df.columns[k].Name = s
@@ -1538,7 +1901,7 @@ func (df DataFrame) Col(colname string) series.Series {
 
 	idx := findInStringSlice(colname, df.Names())
 	if idx < 0 {
-		return series.Series{Err: fmt.Errorf("未知列名")}
+		return series.Series{Err: series.ColumnNotFoundError("Col", colname)}
 	}
 	return df.columns[idx].Copy()
 }
@@ -1546,27 +1909,28 @@ func (df DataFrame) Col(colname string) series.Series {
 // InnerJoin 执行内连接操作，将两个 DataFrame 按照指定的键连接。
 func (df DataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
 	if len(keys) == 0 {
-		return DataFrame{Err: fmt.Errorf("未指定连接键")}
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Join", "no_join_keys")}
 	}
 
 	var iKeysA []int
 	var iKeysB []int
-	var errorArr []string
+	var errorArr []error
 	for _, key := range keys {
 		i := df.colIndex(key)
 		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在左侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "left_key_not_found", key).WithColumn(key))
 		}
 		iKeysA = append(iKeysA, i)
 		j := b.colIndex(key)
 		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在右侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "right_key_not_found", key).WithColumn(key))
 		}
 		iKeysB = append(iKeysB, j)
 	}
 	if len(errorArr) != 0 {
-		return DataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+		return DataFrame{Err: errors.Join(errorArr...)}
 	}
+	matchers := joinMatchers(df, b, iKeysA, iKeysB)
 
 	aCols := df.columns
 	bCols := b.columns
@@ -1594,9 +1958,7 @@ func (df DataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
 		for j := 0; j < b.nrows; j++ {
 			match := true
 			for k := range keys {
-				aElem := aCols[iKeysA[k]].Elem(i)
-				bElem := bCols[iKeysB[k]].Elem(j)
-				match = match && aElem.Eq(bElem)
+				match = match && matchers[k](i, j)
 			}
 			if match {
 				ii := 0
@@ -1624,27 +1986,28 @@ func (df DataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
 // LeftJoin 执行左连接操作，将两个 DataFrame 按照指定的键连接。
 func (df DataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
 	if len(keys) == 0 {
-		return DataFrame{Err: fmt.Errorf("未指定连接键")}
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Join", "no_join_keys")}
 	}
 
 	var iKeysA []int
 	var iKeysB []int
-	var errorArr []string
+	var errorArr []error
 	for _, key := range keys {
 		i := df.colIndex(key)
 		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在左侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "left_key_not_found", key).WithColumn(key))
 		}
 		iKeysA = append(iKeysA, i)
 		j := b.colIndex(key)
 		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在右侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "right_key_not_found", key).WithColumn(key))
 		}
 		iKeysB = append(iKeysB, j)
 	}
 	if len(errorArr) != 0 {
-		return DataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+		return DataFrame{Err: errors.Join(errorArr...)}
 	}
+	matchers := joinMatchers(df, b, iKeysA, iKeysB)
 
 	aCols := df.columns
 	bCols := b.columns
@@ -1673,9 +2036,7 @@ func (df DataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
 		for j := 0; j < b.nrows; j++ {
 			match := true
 			for k := range keys {
-				aElem := aCols[iKeysA[k]].Elem(i)
-				bElem := bCols[iKeysB[k]].Elem(j)
-				match = match && aElem.Eq(bElem)
+				match = match && matchers[k](i, j)
 			}
 			if match {
 				matched = true
@@ -1721,27 +2082,28 @@ func (df DataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
 // RightJoin 执行右连接操作，将两个 DataFrame 按照指定的键连接。
 func (df DataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 	if len(keys) == 0 {
-		return DataFrame{Err: fmt.Errorf("未指定连接键")}
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Join", "no_join_keys")}
 	}
 
 	var iKeysA []int
 	var iKeysB []int
-	var errorArr []string
+	var errorArr []error
 	for _, key := range keys {
 		i := df.colIndex(key)
 		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在左侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "left_key_not_found", key).WithColumn(key))
 		}
 		iKeysA = append(iKeysA, i)
 		j := b.colIndex(key)
 		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在右侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "right_key_not_found", key).WithColumn(key))
 		}
 		iKeysB = append(iKeysB, j)
 	}
 	if len(errorArr) != 0 {
-		return DataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+		return DataFrame{Err: errors.Join(errorArr...)}
 	}
+	matchers := joinMatchers(df, b, iKeysA, iKeysB)
 
 	aCols := df.columns
 	bCols := b.columns
@@ -1772,9 +2134,7 @@ func (df DataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 		for i := 0; i < df.nrows; i++ {
 			match := true
 			for k := range keys {
-				aElem := aCols[iKeysA[k]].Elem(i)
-				bElem := bCols[iKeysB[k]].Elem(j)
-				match = match && aElem.Eq(bElem)
+				match = match && matchers[k](i, j)
 			}
 			if match {
 				matched = true
@@ -1828,27 +2188,28 @@ func (df DataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 // OuterJoin 执行外连接操作，将两个 DataFrame 按照指定的键连接。
 func (df DataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 	if len(keys) == 0 {
-		return DataFrame{Err: fmt.Errorf("未指定连接键")}
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "Join", "no_join_keys")}
 	}
 
 	var iKeysA []int
 	var iKeysB []int
-	var errorArr []string
+	var errorArr []error
 	for _, key := range keys {
 		i := df.colIndex(key)
 		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在左侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "left_key_not_found", key).WithColumn(key))
 		}
 		iKeysA = append(iKeysA, i)
 		j := b.colIndex(key)
 		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("在右侧 DataFrame 中找不到键 %q", key))
+			errorArr = append(errorArr, series.NewError(series.ErrColumnNotFound, "Join", "right_key_not_found", key).WithColumn(key))
 		}
 		iKeysB = append(iKeysB, j)
 	}
 	if len(errorArr) != 0 {
-		return DataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+		return DataFrame{Err: errors.Join(errorArr...)}
 	}
+	matchers := joinMatchers(df, b, iKeysA, iKeysB)
 
 	aCols := df.columns
 	bCols := b.columns
@@ -1877,9 +2238,7 @@ func (df DataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 		for j := 0; j < b.nrows; j++ {
 			match := true
 			for k := range keys {
-				aElem := aCols[iKeysA[k]].Elem(i)
-				bElem := bCols[iKeysB[k]].Elem(j)
-				match = match && aElem.Eq(bElem)
+				match = match && matchers[k](i, j)
 			}
 			if match {
 				matched = true
@@ -1924,9 +2283,7 @@ func (df DataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 		for i := 0; i < df.nrows; i++ {
 			match := true
 			for k := range keys {
-				aElem := aCols[iKeysA[k]].Elem(i)
-				bElem := bCols[iKeysB[k]].Elem(j)
-				match = match && aElem.Eq(bElem)
+				match = match && matchers[k](i, j)
 			}
 			if match {
 				matched = true
@@ -1953,6 +2310,37 @@ func (df DataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 	return New(newCols...)
 }
 
+// joinMatchers 返回判断左右两个DataFrame的行在每个连接键上是否相等的函数。两侧都是 Categorical 的键
+// 通过编码比较，右侧的编码预先映射为左侧的编码；其他键使用 Element.Eq 比较。
+func joinMatchers(a, b DataFrame, iKeysA, iKeysB []int) []func(i, j int) bool {
+	matchers := make([]func(i, j int) bool, len(iKeysA))
+	for k := range iKeysA {
+		colA, colB := a.columns[iKeysA[k]], b.columns[iKeysB[k]]
+		if colA.Type() != series.Categorical || colB.Type() != series.Categorical {
+			matchers[k] = func(i, j int) bool {
+				return colA.Elem(i).Eq(colB.Elem(j))
+			}
+			continue
+		}
+		index := make(map[string]int)
+		for code, c := range colA.Categories() {
+			index[c] = code
+		}
+		toA := make([]int, len(colB.Categories()))
+		for code, c := range colB.Categories() {
+			toA[code] = -1
+			if ca, ok := index[c]; ok {
+				toA[code] = ca
+			}
+		}
+		codesA, codesB := colA.Codes(), colB.Codes()
+		matchers[k] = func(i, j int) bool {
+			return codesA[i] >= 0 && codesB[j] >= 0 && codesA[i] == toA[codesB[j]]
+		}
+	}
+	return matchers
+}
+
 // CrossJoin 执行交叉连接操作，返回两个 DataFrame 的笛卡尔积。
 func (df DataFrame) CrossJoin(b DataFrame) DataFrame {
 	aCols := df.columns
@@ -2022,11 +2410,42 @@ func (df DataFrame) Maps() []map[string]interface{} {
 	return maps
 }
 
-// Elem 返回指定行和列位置的 DataFrame 单元格元素。
+// Elem 返回指定行和列位置的 DataFrame 单元格元素。如果位置超出范围，则返回一个 NaN 元素，
+// 与 series.Series 的 Elem 一样无法与真正的 NaN 区分；需要检测无效位置时使用 ElemE 或 MustElem。
 func (df DataFrame) Elem(r, c int) series.Element {
+	if c < 0 || c >= df.ncols {
+		return series.New(nil, series.String, "").Elem(0)
+	}
 	return df.columns[c].Elem(r)
 }
 
+// ElemE 与 Elem 相同，但在位置超出范围时返回 nil 元素和 ErrIndexOutOfRange 类别的错误。
+func (df DataFrame) ElemE(r, c int) (series.Element, error) {
+	if r < 0 || r >= df.nrows || c < 0 || c >= df.ncols {
+		return nil, series.NewError(series.ErrIndexOutOfRange, "Elem", "")
+	}
+	return df.columns[c].Elem(r), nil
+}
+
+// MustElem 与 Elem 相同，但在位置超出范围时引发 panic。
+func (df DataFrame) MustElem(r, c int) series.Element {
+	e, err := df.ElemE(r, c)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Must 在 DataFrame 带有错误时引发 panic，否则返回 DataFrame 本身。用于希望在出错时立即中止的调用方，例如：
+//
+//	df := dataframe.Must(dataframe.ReadCSV(r).Filter(f))
+func Must(df DataFrame) DataFrame {
+	if df.Err != nil {
+		panic(df.Err)
+	}
+	return df
+}
+
 // fixColnames 修复列名，处理重复和缺失的列名，保证列名的唯一性。
 func fixColnames(colnames []string) {
 	dupnamesidx := make(map[string][]int)
@@ -2102,7 +2521,7 @@ func parseSelectIndexes(l int, indexes SelectIndexes, colnames []string) ([]int,
 	case []bool:
 		bools := indexes.([]bool)
 		if len(bools) != l {
-			return nil, fmt.Errorf("索引错误：索引维度不匹配")
+			return nil, series.NewError(series.ErrDimensionMismatch, "", "index_dimension_mismatch")
 		}
 		for i, b := range bools {
 			if b {
@@ -2113,7 +2532,7 @@ func parseSelectIndexes(l int, indexes SelectIndexes, colnames []string) ([]int,
 		s := indexes.(string)
 		i := findInStringSlice(s, colnames)
 		if i < 0 {
-			return nil, fmt.Errorf("无法选择列：找不到列名 %q", s)
+			return nil, series.ColumnNotFoundError("", s)
 		}
 		idx = append(idx, i)
 	case []string:
@@ -2121,17 +2540,17 @@ func parseSelectIndexes(l int, indexes SelectIndexes, colnames []string) ([]int,
 		for _, s := range xs {
 			i := findInStringSlice(s, colnames)
 			if i < 0 {
-				return nil, fmt.Errorf("无法选择列：找不到列名 %q", s)
+				return nil, series.ColumnNotFoundError("", s)
 			}
 			idx = append(idx, i)
 		}
 	case series.Series:
 		s := indexes.(series.Series)
 		if err := s.Err; err != nil {
-			return nil, fmt.Errorf("索引错误：新值存在错误：%v", err)
+			return nil, series.NewError(series.ErrInvalidIndex, "", "argument_has_errors").Wrap(err)
 		}
 		if s.HasNaN() {
-			return nil, fmt.Errorf("索引错误：索引包含 NaN")
+			return nil, series.NewError(series.ErrInvalidIndex, "", "index_has_nan")
 		}
 		switch s.Type() {
 		case series.Int:
@@ -2139,30 +2558,36 @@ func parseSelectIndexes(l int, indexes SelectIndexes, colnames []string) ([]int,
 		case series.Bool:
 			bools, err := s.Bool()
 			if err != nil {
-				return nil, fmt.Errorf("索引错误：%v", err)
+				return nil, series.NewError(series.ErrInvalidIndex, "", "").Wrap(err)
 			}
 			return parseSelectIndexes(l, bools, colnames)
 		case series.String:
 			xs := indexes.(series.Series).Records()
 			return parseSelectIndexes(l, xs, colnames)
 		default:
-			return nil, fmt.Errorf("索引错误：未知的索引模式")
+			return nil, series.NewError(series.ErrInvalidIndex, "", "unknown_index_mode")
 		}
 	default:
-		return nil, fmt.Errorf("索引错误：未知的索引模式")
+		return nil, series.NewError(series.ErrInvalidIndex, "", "unknown_index_mode")
 	}
 	return idx, nil
 }
 
-// findType 查找字符串切片的元素类型，返回对应的 series.Type。
+// findType 查找字符串切片的元素类型，返回对应的 series.Type。包含超出 int 范围的非负整数且没有负数时为 Uint64。
 func findType(arr []string) (series.Type, error) {
-	var hasFloats, hasInts, hasBools, hasStrings bool
+	var hasFloats, hasInts, hasNegInts, hasUints, hasBools, hasStrings bool
 	for _, str := range arr {
 		if str == "" || str == "NaN" {
 			continue
 		}
-		if _, err := strconv.Atoi(str); err == nil {
+		if i, err := strconv.Atoi(str); err == nil {
 			hasInts = true
+			hasNegInts = hasNegInts || i < 0
+			continue
+		}
+		// 超出 int 范围的无符号整数，例如 uint64 编号
+		if _, err := strconv.ParseUint(str, 10, 64); err == nil {
+			hasUints = true
 			continue
 		}
 		if _, err := strconv.ParseFloat(str, 64); err == nil {
@@ -2181,12 +2606,14 @@ func findType(arr []string) (series.Type, error) {
 		return series.String, nil
 	case hasBools:
 		return series.Bool, nil
-	case hasFloats:
+	case hasFloats, hasUints && hasNegInts:
 		return series.Float, nil
+	case hasUints:
+		return series.Uint64, nil
 	case hasInts:
 		return series.Int, nil
 	default:
-		return series.String, fmt.Errorf("无法检测到类型")
+		return series.String, series.NewError(series.ErrUnknownType, "", "type_detection")
 	}
 }
 
@@ -2221,23 +2648,23 @@ func inIntSlice(i int, is []int) bool {
 // Describe 返回 DataFrame 的描述性统计信息。
 func (df DataFrame) Describe() DataFrame {
 	labels := series.Strings([]string{
-		"平均值",
-		"中位数",
-		"标准差",
-		"最小值",
+		series.Message("describe_mean"),
+		series.Message("describe_median"),
+		series.Message("describe_std"),
+		series.Message("describe_min"),
 		"25%",
 		"50%",
 		"75%",
-		"最大值",
+		series.Message("describe_max"),
 	})
-	labels.Name = "列名"
+	labels.Name = series.Message("describe_column")
 
 	ss := []series.Series{labels}
 
 	for _, col := range df.columns {
 		var newCol series.Series
 		switch col.Type() {
-		case series.String:
+		case series.String, series.Categorical:
 			newCol = series.New([]string{
 				"-",
 				"-",
@@ -2248,14 +2675,15 @@ func (df DataFrame) Describe() DataFrame {
 				"-",
 				col.MaxStr(),
 			},
-				col.Type(),
+				series.String,
 				col.Name,
 			)
 		case series.Bool:
 			fallthrough
-		case series.Float:
+		case series.Float, series.Decimal:
 			fallthrough
-		case series.Int:
+		case series.Int, series.Int8, series.Int16, series.Int32, series.Int64,
+			series.Uint8, series.Uint16, series.Uint32, series.Uint64:
 			newCol = series.New([]float64{
 				col.Mean(),
 				col.Median(),
@@ -2269,6 +2697,18 @@ func (df DataFrame) Describe() DataFrame {
 				series.Float,
 				col.Name,
 			)
+		default:
+			// 注册类型按照排序结果取最小值和最大值
+			min, max := "-", "-"
+			if order := col.Order(false); len(order) > 0 && !col.Elem(order[0]).IsNA() {
+				min = col.Elem(order[0]).String()
+				for _, i := range order {
+					if !col.Elem(i).IsNA() {
+						max = col.Elem(i).String()
+					}
+				}
+			}
+			newCol = series.New([]string{"-", "-", "-", min, "-", "-", "-", max}, series.String, col.Name)
 		}
 		ss = append(ss, newCol)
 	}
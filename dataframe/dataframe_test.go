@@ -0,0 +1,40 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// checkRecords 检查 DataFrame 没有错误，并且包含表头在内的记录与期望一致。
+func checkRecords(t *testing.T, df DataFrame, want [][]string) {
+	t.Helper()
+	if df.Err != nil {
+		t.Fatalf("unexpected error: %v", df.Err)
+	}
+	if got := df.Records(); !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+}
+
+func TestArrangeCollation(t *testing.T) {
+	df := New(
+		series.New([]string{"张飞", "刘备", "曹操", "关羽"}, series.String, "name"),
+		series.New([]int{3, 1, 4, 2}, series.Int, "id"),
+	)
+	checkRecords(t, df.Arrange(Sort("name", series.CollatePinyin())), [][]string{
+		{"name", "id"},
+		{"曹操", "4"},
+		{"关羽", "2"},
+		{"刘备", "1"},
+		{"张飞", "3"},
+	})
+	checkRecords(t, df.Arrange(RevSort("name", series.CollatePinyin())), [][]string{
+		{"name", "id"},
+		{"张飞", "3"},
+		{"刘备", "1"},
+		{"关羽", "2"},
+		{"曹操", "4"},
+	})
+}
@@ -0,0 +1,162 @@
+package dataframe
+
+import (
+	"golang.org/x/text/width"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DisplayOption 是用于配置DataFrame显示方式的函数类型。
+type DisplayOption func(*displayOptions)
+
+// displayOptions 结构包含打印DataFrame时的各种选项。
+type displayOptions struct {
+	maxRows        int    // 最多显示的行数
+	maxCols        int    // 最多显示的列数，0 表示只受总宽度限制
+	maxWidth       int    // 一行最多占用的终端宽度
+	maxColWidth    int    // 单元格最多占用的终端宽度，0 表示不限制
+	floatPrecision int    // Float 列的小数位数，负数表示使用默认格式
+	naRep          string // NaN 元素的显示方式
+	headTail       bool   // 行数过多时是否同时显示开头和结尾的行
+}
+
+var (
+	displayMu sync.RWMutex
+	display   = defaultDisplayOptions()
+)
+
+// defaultDisplayOptions 返回默认的显示选项。
+func defaultDisplayOptions() displayOptions {
+	return displayOptions{
+		maxRows:        10,
+		maxCols:        0,
+		maxWidth:       70,
+		maxColWidth:    0,
+		floatPrecision: -1,
+		naRep:          "NaN",
+		headTail:       false,
+	}
+}
+
+// DisplayMaxRows 函数返回一个DisplayOption，用于设置最多显示的行数。
+func DisplayMaxRows(n int) DisplayOption {
+	return func(c *displayOptions) {
+		c.maxRows = n
+	}
+}
+
+// DisplayMaxCols 函数返回一个DisplayOption，用于设置最多显示的列数，0 表示只受总宽度限制。
+func DisplayMaxCols(n int) DisplayOption {
+	return func(c *displayOptions) {
+		c.maxCols = n
+	}
+}
+
+// DisplayMaxWidth 函数返回一个DisplayOption，用于设置一行最多占用的终端宽度。
+func DisplayMaxWidth(n int) DisplayOption {
+	return func(c *displayOptions) {
+		c.maxWidth = n
+	}
+}
+
+// DisplayMaxColWidth 函数返回一个DisplayOption，用于设置单元格最多占用的终端宽度，超出部分以 "…" 截断。
+// 0 表示不限制。
+func DisplayMaxColWidth(n int) DisplayOption {
+	return func(c *displayOptions) {
+		c.maxColWidth = n
+	}
+}
+
+// DisplayFloatPrecision 函数返回一个DisplayOption，用于设置 Float 列显示的小数位数，负数表示使用默认格式。
+func DisplayFloatPrecision(p int) DisplayOption {
+	return func(c *displayOptions) {
+		c.floatPrecision = p
+	}
+}
+
+// DisplayNA 函数返回一个DisplayOption，用于设置 NaN 元素的显示方式。
+func DisplayNA(s string) DisplayOption {
+	return func(c *displayOptions) {
+		c.naRep = s
+	}
+}
+
+// DisplayHeadTail 函数返回一个DisplayOption，用于设置行数过多时是否同时显示开头和结尾的行。
+func DisplayHeadTail(b bool) DisplayOption {
+	return func(c *displayOptions) {
+		c.headTail = b
+	}
+}
+
+// SetDisplayOptions 修改全局的显示选项，影响之后所有的 String 和 Display 调用。
+func SetDisplayOptions(options ...DisplayOption) {
+	displayMu.Lock()
+	defer displayMu.Unlock()
+	for _, option := range options {
+		option(&display)
+	}
+}
+
+// ResetDisplayOptions 将全局的显示选项恢复为默认值。
+func ResetDisplayOptions() {
+	displayMu.Lock()
+	defer displayMu.Unlock()
+	display = defaultDisplayOptions()
+}
+
+// currentDisplayOptions 返回在全局显示选项基础上应用 options 后的显示选项。
+func currentDisplayOptions(options ...DisplayOption) displayOptions {
+	displayMu.RLock()
+	cfg := display
+	displayMu.RUnlock()
+	for _, option := range options {
+		option(&cfg)
+	}
+	return cfg
+}
+
+// Display 返回使用给定显示选项的 DataFrame 字符串表示，未指定的选项使用全局设置。
+func (df DataFrame) Display(options ...DisplayOption) string {
+	return df.print(true, true, true, true, currentDisplayOptions(options...), "DataFrame")
+}
+
+// displayWidth 返回字符串在终端中占用的宽度，东亚宽字符和全角字符占两个单元格，组合字符不占宽度。
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth 返回字符在终端中占用的宽度。
+func runeWidth(r rune) int {
+	if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// truncateWidth 将字符串截断到最多占用 max 个单元格，被截断时以 "…" 结尾。
+func truncateWidth(s string, max int) string {
+	if max <= 0 || displayWidth(s) <= max {
+		return s
+	}
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if w+rw > max-1 {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	b.WriteString("…")
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package dataframe
+
+import (
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"abc", 3},
+		{"张三", 4},
+		{"ＡＢ", 4},
+		{"e\u0301", 1},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := displayWidth(tt.s); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"abcdef", 4, "abc…"},
+		{"张三丰", 5, "张三…"},
+		{"张三丰", 4, "张…"},
+		{"abc", 3, "abc"},
+		{"abc", 0, "abc"},
+	}
+	for _, tt := range tests {
+		if got := truncateWidth(tt.s, tt.max); got != tt.want {
+			t.Errorf("truncateWidth(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayAlignment(t *testing.T) {
+	df := New(
+		series.New([]string{"张三", "Bob"}, series.String, "Name"),
+		series.New([]int{25, 30}, series.Int, "Age"),
+	)
+	lines := strings.Split(df.Display(), "\n")
+	// 表头、两行数据和类型行中 Age 列的起始位置应当对齐
+	col := -1
+	for _, line := range lines[2:6] {
+		fields := strings.Fields(line)
+		last := fields[len(fields)-1]
+		pos := displayWidth(line[:strings.LastIndex(line, last)])
+		if col >= 0 && pos != col {
+			t.Fatalf("misaligned output:\n%s", strings.Join(lines, "\n"))
+		}
+		col = pos
+	}
+}
+
+func TestDisplayOptions(t *testing.T) {
+	df := New(
+		series.New([]interface{}{1.23456, nil, 3.0, 4.0, 5.0}, series.Float, "x"),
+		series.New([]string{"a", "b", "c", "d", "一二三四五"}, series.String, "s"),
+	)
+	tests := []struct {
+		name     string
+		options  []DisplayOption
+		contains []string
+		excludes []string
+	}{
+		{"precision and na", []DisplayOption{DisplayFloatPrecision(2), DisplayNA("-")}, []string{"1.23 ", " -  "}, []string{"NaN"}},
+		{"max rows", []DisplayOption{DisplayMaxRows(2)}, []string{" 1:", "..."}, []string{" 2:"}},
+		{"head tail", []DisplayOption{DisplayMaxRows(2), DisplayHeadTail(true)}, []string{" 0:", "...", " 4:"}, []string{" 1:"}},
+		{"max col width", []DisplayOption{DisplayMaxColWidth(5)}, []string{"一二…"}, []string{"一二三四五"}},
+		{"max cols", []DisplayOption{DisplayMaxCols(1)}, []string{"Not Showing: s <string>"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := df.Display(tt.options...)
+			for _, s := range tt.contains {
+				if !strings.Contains(out, s) {
+					t.Errorf("output does not contain %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(out, s) {
+					t.Errorf("output contains %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDisplayOptions(t *testing.T) {
+	defer ResetDisplayOptions()
+	df := New(series.New([]interface{}{nil}, series.Float, "x"))
+	SetDisplayOptions(DisplayNA("<NA>"))
+	if out := df.String(); !strings.Contains(out, "<NA>") {
+		t.Errorf("global option not applied:\n%s", out)
+	}
+	if out := df.Display(DisplayNA("null")); !strings.Contains(out, "null") {
+		t.Errorf("per-call option not applied:\n%s", out)
+	}
+	ResetDisplayOptions()
+	if out := df.String(); !strings.Contains(out, "NaN") {
+		t.Errorf("reset did not restore defaults:\n%s", out)
+	}
+}
@@ -0,0 +1,97 @@
+package dataframe
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"stream/go-sdk/test/gota_study/series"
+	"strings"
+)
+
+// WithEncoding 函数返回一个LoadOption，用于设置输入的字符编码，例如 "gbk"、"gb18030"、"big5"、
+// "utf-16le" 和 "utf-16be"，支持 WHATWG 编码标准中的名称和别名。无论是否设置编码，输入开头的
+// UTF-8 或 UTF-16 BOM 都会被识别并去除，BOM 表示的编码优先于设置的编码。
+func WithEncoding(name string) LoadOption {
+	return func(c *loadOptions) {
+		c.encoding = name
+	}
+}
+
+// WriteEncoding 函数返回一个WriteOption，用于设置输出的字符编码，支持的名称与 WithEncoding 相同。
+func WriteEncoding(name string) WriteOption {
+	return func(c *writeOptions) {
+		c.encoding = name
+	}
+}
+
+// WriteBOM 函数返回一个WriteOption，用于设置是否在 UTF-8 或 UTF-16 输出的开头写入 BOM，
+// 例如 Excel 需要 BOM 才能正确识别 UTF-8 编码的 CSV 文件。其他编码忽略该选项。
+func WriteBOM(b bool) WriteOption {
+	return func(c *writeOptions) {
+		c.bom = b
+	}
+}
+
+// lookupEncoding 根据名称查找字符编码。
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, series.NewError(series.ErrInvalidArgument, "", "unknown_encoding", name)
+	}
+	return enc, nil
+}
+
+// decodeReader 返回将输入从指定编码转换为 UTF-8 并去除 BOM 的 io.Reader，name 为空表示 UTF-8。
+func decodeReader(r io.Reader, name string) (io.Reader, error) {
+	dec := encoding.Nop.NewDecoder()
+	if name != "" {
+		enc, err := lookupEncoding(name)
+		if err != nil {
+			return nil, err
+		}
+		dec = enc.NewDecoder()
+	}
+	return transform.NewReader(r, unicode.BOMOverride(dec)), nil
+}
+
+// encodeWriter 返回将 UTF-8 输出转换为指定编码的 io.WriteCloser，name 为空表示 UTF-8。
+// 写入完成后必须调用 Close 以输出缓冲的数据，Close 不会关闭 w。
+func encodeWriter(w io.Writer, name string, bom bool) (io.WriteCloser, error) {
+	if name == "" {
+		name = "utf-8"
+	}
+	enc, err := lookupEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+	switch canonical, _ := htmlindex.Name(enc); strings.ToLower(canonical) {
+	case "utf-8":
+		if bom {
+			if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+				return nil, err
+			}
+		}
+		return nopWriteCloser{w}, nil
+	case "utf-16le":
+		if bom {
+			enc = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+		}
+	case "utf-16be":
+		if bom {
+			enc = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+		}
+	}
+	return transform.NewWriter(w, enc.NewEncoder()), nil
+}
+
+// nopWriteCloser 是 Close 不做任何操作的 io.WriteCloser。
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close 不做任何操作。
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVEncoding(t *testing.T) {
+	want := [][]string{{"名称", "值"}, {"中文", "1"}}
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+	}{
+		{"utf-8", []byte("名称,值\n中文,1\n"), ""},
+		{"utf-8 bom", append([]byte{0xEF, 0xBB, 0xBF}, "名称,值\n中文,1\n"...), ""},
+		{"gbk", []byte{0xC3, 0xFB, 0xB3, 0xC6, ',', 0xD6, 0xB5, '\n', 0xD6, 0xD0, 0xCE, 0xC4, ',', '1', '\n'}, "gbk"},
+		{"gb18030 alias", []byte{0xC3, 0xFB, 0xB3, 0xC6, ',', 0xD6, 0xB5, '\n', 0xD6, 0xD0, 0xCE, 0xC4, ',', '1', '\n'}, "GB18030"},
+		{"utf-16le bom", utf16le("\uFEFF名称,值\n中文,1\n"), ""},
+		{"bom overrides encoding", utf16le("\uFEFF名称,值\n中文,1\n"), "gbk"},
+		{"utf-16le", utf16le("名称,值\n中文,1\n"), "utf-16le"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options []LoadOption
+			if tt.encoding != "" {
+				options = append(options, WithEncoding(tt.encoding))
+			}
+			checkRecords(t, ReadCSV(bytes.NewReader(tt.data), options...), want)
+		})
+	}
+
+	df := ReadCSV(strings.NewReader("a\n1\n"), WithEncoding("no-such-encoding"))
+	if !errors.Is(df.Err, ErrInvalidArgument) {
+		t.Errorf("unknown encoding err = %v, want %v", df.Err, ErrInvalidArgument)
+	}
+}
+
+func TestWriteCSVEncoding(t *testing.T) {
+	df := ReadCSV(strings.NewReader("名称,值\n中文,1\n"))
+	tests := []struct {
+		name     string
+		encoding string
+		bom      bool
+		prefix   []byte
+	}{
+		{"utf-8", "", false, []byte("名称")},
+		{"utf-8 bom", "utf-8", true, []byte{0xEF, 0xBB, 0xBF}},
+		{"gbk", "gbk", false, []byte{0xC3, 0xFB, 0xB3, 0xC6}},
+		{"gbk ignores bom", "gbk", true, []byte{0xC3, 0xFB, 0xB3, 0xC6}},
+		{"utf-16le bom", "utf-16le", true, []byte{0xFF, 0xFE}},
+		{"utf-16be", "utf-16be", false, []byte{0x54, 0x0D}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			options := []WriteOption{WriteBOM(tt.bom)}
+			if tt.encoding != "" {
+				options = append(options, WriteEncoding(tt.encoding))
+			}
+			if err := df.WriteCSV(&buf, options...); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), tt.prefix) {
+				t.Errorf("output starts with % x, want % x", buf.Bytes()[:len(tt.prefix)], tt.prefix)
+			}
+			var load []LoadOption
+			if tt.encoding != "" {
+				load = append(load, WithEncoding(tt.encoding))
+			}
+			checkRecords(t, ReadCSV(&buf, load...), df.Records())
+		})
+	}
+
+	if err := df.WriteCSV(&bytes.Buffer{}, WriteEncoding("no-such-encoding")); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown encoding err = %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+// utf16le 将字符串编码为 UTF-16LE。
+func utf16le(s string) []byte {
+	var b []byte
+	for _, r := range s {
+		b = append(b, byte(r), byte(r>>8))
+	}
+	return b
+}
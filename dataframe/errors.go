@@ -0,0 +1,24 @@
+package dataframe
+
+import "stream/go-sdk/test/gota_study/series"
+
+// 错误类别，与 series 包中的定义相同。DataFrame 和 Groups 的 Err 字段可以使用 errors.Is 判断类别，
+// 使用 errors.As 获取 *series.Error 中的操作名称和列名等信息。
+const (
+	ErrColumnNotFound    = series.ErrColumnNotFound
+	ErrDimensionMismatch = series.ErrDimensionMismatch
+	ErrUnknownType       = series.ErrUnknownType
+	ErrIndexOutOfRange   = series.ErrIndexOutOfRange
+	ErrInvalidIndex      = series.ErrInvalidIndex
+	ErrUnknownComparator = series.ErrUnknownComparator
+	ErrConversion        = series.ErrConversion
+	ErrEmpty             = series.ErrEmpty
+	ErrInvalidArgument   = series.ErrInvalidArgument
+)
+
+// columnError 使用操作名称 op 包装在列 colname 上发生的错误 err。
+func columnError(op, colname string, err error) error {
+	e := series.WrapError(op, err).WithColumn(colname)
+	e.Msg, e.Args = "column_error", []interface{}{colname}
+	return e
+}
@@ -0,0 +1,99 @@
+package dataframe
+
+import (
+	"errors"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestColumnErrors(t *testing.T) {
+	df := New(series.New([]int{1, 2}, series.Int, "x"))
+	tests := []struct {
+		name string
+		err  error
+		kind series.ErrorKind
+	}{
+		{"select", df.Select("y").Err, ErrColumnNotFound},
+		{"rename", df.Rename("z", "y").Err, ErrColumnNotFound},
+		{"group by", df.GroupBy("y").Err, ErrColumnNotFound},
+		{"mutate", df.Mutate(series.New([]int{1}, series.Int, "y")).Err, ErrDimensionMismatch},
+		{"join keys", df.InnerJoin(df).Err, ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.kind) {
+				t.Errorf("err = %v, want %v", tt.err, tt.kind)
+			}
+		})
+	}
+	var e *series.Error
+	if err := df.Rename("z", "y").Err; !errors.As(err, &e) || e.Column != "y" {
+		t.Errorf("column not recorded: %+v", e)
+	}
+}
+
+func TestDescribeLocale(t *testing.T) {
+	defer series.SetLocale("zh")
+	df := New(series.New([]float64{1, 2, 3}, series.Float, "x"))
+	tests := []struct {
+		locale string
+		header string
+		first  string
+	}{
+		{"zh", "列名", "平均值"},
+		{"en", "column", "mean"},
+	}
+	for _, tt := range tests {
+		if err := series.SetLocale(tt.locale); err != nil {
+			t.Fatal(err)
+		}
+		records := df.Describe().Records()
+		if records[0][0] != tt.header || records[1][0] != tt.first {
+			t.Errorf("%s: labels = %q %q, want %q %q", tt.locale, records[0][0], records[1][0], tt.header, tt.first)
+		}
+	}
+}
+
+func TestNoPanics(t *testing.T) {
+	df := New(series.New([]int{1, 2}, series.Int, "x"), series.New([]string{"a", "b"}, series.String, "s"))
+	tests := []struct {
+		name string
+		err  error
+		kind series.ErrorKind
+	}{
+		{"unknown filter aggregation", df.FilterAggregation(Aggregation(9), F{Colname: "x", Comparator: series.Eq, Comparando: 1}).Err, ErrInvalidArgument},
+		{"bad comparator", df.Filter(F{Colname: "x", Comparator: "~", Comparando: 1}).Err, ErrUnknownComparator},
+		{"subset out of range", df.Subset([]int{7}).Err, ErrIndexOutOfRange},
+		{"rapply type", New(series.New([]string{"1.5"}, series.Decimal, "d")).Rapply(func(s series.Series) series.Series { return s }).Err, ErrUnknownType},
+		{"unknown aggregation", df.GroupBy("s").Aggregation([]AggregationType{0}, []string{"x"}).Err, ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.kind) {
+				t.Errorf("err = %v, want %v", tt.err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestElemOutOfRange(t *testing.T) {
+	df := New(series.New([]int{1}, series.Int, "x"))
+	for _, pos := range [][2]int{{0, 1}, {1, 0}, {-1, 0}} {
+		if e := df.Elem(pos[0], pos[1]); !e.IsNA() {
+			t.Errorf("Elem(%d, %d) = %v, want NaN", pos[0], pos[1], e)
+		}
+		if e, err := df.ElemE(pos[0], pos[1]); e != nil || !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("ElemE(%d, %d) = %v, %v, want nil, %v", pos[0], pos[1], e, err, ErrIndexOutOfRange)
+		}
+	}
+	if e, err := df.ElemE(0, 0); err != nil || e.String() != "1" {
+		t.Errorf("ElemE(0, 0) = %v, %v, want 1, nil", e, err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustElem did not panic")
+		}
+	}()
+	df.MustElem(0, 3)
+}
@@ -0,0 +1,337 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// Orient 表示 JSON 数据的结构。
+type Orient string
+
+// 支持的 JSON 结构
+const (
+	OrientRecords Orient = "records" // [{"列名": 值, ...}, ...]，默认结构
+	OrientColumns Orient = "columns" // {"列名": [值, ...], ...}
+	OrientSplit   Orient = "split"   // {"columns": [列名, ...], "data": [[值, ...], ...]}
+	OrientIndex   Orient = "index"   // {"行索引": {"列名": 值, ...}, ...}
+	OrientValues  Orient = "values"  // [[值, ...], ...]，读取时列名为 X0、X1……
+)
+
+// WithOrient 函数返回一个LoadOption，用于设置 ReadJSON 输入的 JSON 结构，默认为 OrientRecords。
+func WithOrient(o Orient) LoadOption {
+	return func(c *loadOptions) {
+		c.orient = o
+	}
+}
+
+// WriteOrient 函数返回一个WriteOption，用于设置 WriteJSON 输出的 JSON 结构，默认为 OrientRecords。
+func WriteOrient(o Orient) WriteOption {
+	return func(c *writeOptions) {
+		c.orient = o
+	}
+}
+
+// WriteFloatPrecision 函数返回一个WriteOption，用于设置 JSON 输出中 Float 列的小数位数，负数表示使用能精确表示数值的最短格式。
+func WriteFloatPrecision(p int) WriteOption {
+	return func(c *writeOptions) {
+		c.floatPrecision = p
+	}
+}
+
+// jsonObject 是保留键顺序的 JSON 对象，值在需要时再解析。
+type jsonObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// UnmarshalJSON 解析 JSON 对象并记录键出现的顺序，重复的键保留最后一个值。
+func (o *jsonObject) UnmarshalJSON(b []byte) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != json.Delim('{') {
+		return series.NewError(series.ErrInvalidArgument, "", "json_not_object")
+	}
+	o.keys = nil
+	o.values = make(map[string]json.RawMessage)
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		key := t.(string)
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			return err
+		}
+		if _, ok := o.values[key]; !ok {
+			o.keys = append(o.keys, key)
+		}
+		o.values[key] = raw
+	}
+	_, err = d.Token()
+	return err
+}
+
+// decodeJSONValue 解析一个 JSON 值，数字解析为 json.Number。
+func decodeJSONValue(raw json.RawMessage) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// decodeJSONValues 解析 JSON 数组中的每个值。
+func decodeJSONValues(raws []json.RawMessage) ([]interface{}, error) {
+	values := make([]interface{}, len(raws))
+	for i, raw := range raws {
+		v, err := decodeJSONValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// objectRows 将保留键顺序的 JSON 对象转换为行，列按照键在所有对象中首次出现的顺序排列。
+func objectRows(objs []jsonObject) ([]string, [][]interface{}, error) {
+	var colnames []string
+	seen := make(map[string]bool)
+	for _, o := range objs {
+		for _, k := range o.keys {
+			if !seen[k] {
+				seen[k] = true
+				colnames = append(colnames, k)
+			}
+		}
+	}
+	rows := make([][]interface{}, len(objs))
+	for i, o := range objs {
+		row := make([]interface{}, len(colnames))
+		for j, colname := range colnames {
+			raw, ok := o.values[colname]
+			if !ok {
+				row[j] = missingValue{}
+				continue
+			}
+			v, err := decodeJSONValue(raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			row[j] = v
+		}
+		rows[i] = row
+	}
+	return colnames, rows, nil
+}
+
+// loadObjects 加载保留键顺序的 JSON 对象，列按照键首次出现的顺序排列。
+func loadObjects(objs []jsonObject, options ...LoadOption) DataFrame {
+	colnames, rows, err := objectRows(objs)
+	if err != nil {
+		return DataFrame{Err: err}
+	}
+	return loadRows(colnames, rows, options...)
+}
+
+// parseJSON 按照结构 orient 将 JSON 输入解析为列名和行。
+func parseJSON(d *json.Decoder, orient Orient) ([]string, [][]interface{}, error) {
+	switch orient {
+	case OrientRecords, "":
+		var objs []jsonObject
+		if err := d.Decode(&objs); err != nil {
+			return nil, nil, err
+		}
+		return objectRows(objs)
+	case OrientIndex:
+		var index jsonObject
+		if err := d.Decode(&index); err != nil {
+			return nil, nil, err
+		}
+		objs := make([]jsonObject, len(index.keys))
+		for i, k := range index.keys {
+			if err := json.Unmarshal(index.values[k], &objs[i]); err != nil {
+				return nil, nil, err
+			}
+		}
+		return objectRows(objs)
+	case OrientColumns:
+		var cols jsonObject
+		if err := d.Decode(&cols); err != nil {
+			return nil, nil, err
+		}
+		columns := make([][]json.RawMessage, len(cols.keys))
+		for i, k := range cols.keys {
+			if err := json.Unmarshal(cols.values[k], &columns[i]); err != nil {
+				return nil, nil, err
+			}
+			if len(columns[i]) != len(columns[0]) {
+				return nil, nil, series.NewError(series.ErrDimensionMismatch, "", "column_lengths").WithColumn(k)
+			}
+		}
+		var rows [][]interface{}
+		if len(columns) > 0 {
+			rows = make([][]interface{}, len(columns[0]))
+		}
+		for j := range rows {
+			rows[j] = make([]interface{}, len(columns))
+			for i := range columns {
+				v, err := decodeJSONValue(columns[i][j])
+				if err != nil {
+					return nil, nil, err
+				}
+				rows[j][i] = v
+			}
+		}
+		return cols.keys, rows, nil
+	case OrientSplit:
+		var split struct {
+			Columns []string            `json:"columns"`
+			Data    [][]json.RawMessage `json:"data"`
+		}
+		if err := d.Decode(&split); err != nil {
+			return nil, nil, err
+		}
+		return splitRows(split.Columns, split.Data)
+	case OrientValues:
+		var data [][]json.RawMessage
+		if err := d.Decode(&data); err != nil {
+			return nil, nil, err
+		}
+		var colnames []string
+		if len(data) > 0 {
+			colnames = make([]string, len(data[0]))
+			fixColnames(colnames)
+		}
+		return splitRows(colnames, data)
+	}
+	return nil, nil, series.NewError(series.ErrInvalidArgument, "", "unknown_orient", orient)
+}
+
+// splitRows 解析每行的值，每行的值的个数必须与列数相同。
+func splitRows(colnames []string, data [][]json.RawMessage) ([]string, [][]interface{}, error) {
+	rows := make([][]interface{}, len(data))
+	for j, raws := range data {
+		if len(raws) != len(colnames) {
+			return nil, nil, series.NewError(series.ErrDimensionMismatch, "", "ragged_row", j, len(raws), len(colnames))
+		}
+		row, err := decodeJSONValues(raws)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows[j] = row
+	}
+	return colnames, rows, nil
+}
+
+// jsonValue 返回元素在 JSON 输出中的值，NaN 和无穷大输出为 null，Decimal 元素以标准形式输出为数字。
+func jsonValue(e series.Element, cfg writeOptions) interface{} {
+	if e.IsNA() {
+		return nil
+	}
+	if e.Type() == series.Decimal {
+		return json.Number(e.String())
+	}
+	if e.Type() == series.Float {
+		f := e.Float()
+		if math.IsInf(f, 0) {
+			return nil
+		}
+		if cfg.floatPrecision >= 0 {
+			return json.Number(strconv.FormatFloat(f, 'f', cfg.floatPrecision, 64))
+		}
+	}
+	return e.Val()
+}
+
+// orderedObject 是按照给定顺序输出键的 JSON 对象。
+type orderedObject struct {
+	keys   []string
+	values []interface{}
+}
+
+// MarshalJSON 按照 keys 的顺序输出对象。
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonRow 返回第 i 行的值。
+func (df DataFrame) jsonRow(i int, cfg writeOptions) []interface{} {
+	row := make([]interface{}, df.ncols)
+	for j, col := range df.columns {
+		row[j] = jsonValue(col.Elem(i), cfg)
+	}
+	return row
+}
+
+// jsonRecord 返回第 i 行按列顺序输出的对象。
+func (df DataFrame) jsonRecord(i int, cfg writeOptions) orderedObject {
+	return orderedObject{keys: df.Names(), values: df.jsonRow(i, cfg)}
+}
+
+// jsonDocument 按照结构 cfg.orient 返回 DataFrame 的 JSON 表示，对象的键按照列的顺序输出。
+func (df DataFrame) jsonDocument(cfg writeOptions) (interface{}, error) {
+	switch cfg.orient {
+	case OrientRecords, "":
+		records := make([]orderedObject, df.nrows)
+		for i := range records {
+			records[i] = df.jsonRecord(i, cfg)
+		}
+		return records, nil
+	case OrientIndex:
+		index := orderedObject{keys: make([]string, df.nrows), values: make([]interface{}, df.nrows)}
+		for i := 0; i < df.nrows; i++ {
+			index.keys[i] = strconv.Itoa(i)
+			index.values[i] = df.jsonRecord(i, cfg)
+		}
+		return index, nil
+	case OrientColumns:
+		cols := orderedObject{keys: df.Names(), values: make([]interface{}, df.ncols)}
+		for j, col := range df.columns {
+			values := make([]interface{}, df.nrows)
+			for i := range values {
+				values[i] = jsonValue(col.Elem(i), cfg)
+			}
+			cols.values[j] = values
+		}
+		return cols, nil
+	case OrientSplit, OrientValues:
+		data := make([][]interface{}, df.nrows)
+		for i := range data {
+			data[i] = df.jsonRow(i, cfg)
+		}
+		if cfg.orient == OrientValues {
+			return data, nil
+		}
+		return orderedObject{keys: []string{"columns", "data"}, values: []interface{}{df.Names(), data}}, nil
+	}
+	return nil, series.NewError(series.ErrInvalidArgument, "", "unknown_orient", cfg.orient)
+}
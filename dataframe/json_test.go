@@ -0,0 +1,116 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestWriteJSONOrient(t *testing.T) {
+	df := New(
+		series.New([]string{"x", "y"}, series.String, "b"),
+		series.New([]float64{0.1, 2}, series.Float, "a"),
+		series.New([]interface{}{1, nil}, series.Int, "c"),
+	)
+	tests := []struct {
+		orient Orient
+		want   string
+	}{
+		{OrientRecords, `[{"b":"x","a":0.1,"c":1},{"b":"y","a":2,"c":null}]`},
+		{OrientColumns, `{"b":["x","y"],"a":[0.1,2],"c":[1,null]}`},
+		{OrientSplit, `{"columns":["b","a","c"],"data":[["x",0.1,1],["y",2,null]]}`},
+		{OrientIndex, `{"0":{"b":"x","a":0.1,"c":1},"1":{"b":"y","a":2,"c":null}}`},
+		{OrientValues, `[["x",0.1,1],["y",2,null]]`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.orient), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := df.WriteJSON(&buf, WriteOrient(tt.orient)); err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+
+			back := ReadJSON(&buf, WithOrient(tt.orient))
+			if tt.orient == OrientValues {
+				back = back.Rename("b", "X0").Rename("a", "X1").Rename("c", "X2")
+			}
+			checkRecords(t, back, df.Records())
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := df.WriteJSON(&buf, WriteOrient("table")); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown orient err = %v, want %v", err, ErrInvalidArgument)
+	}
+}
+
+func TestWriteJSONFloatPrecision(t *testing.T) {
+	df := New(series.New([]float64{1.0 / 3, 2, 1e21}, series.Float, "f"))
+	tests := []struct {
+		precision int
+		want      string
+	}{
+		{-1, `[{"f":0.3333333333333333},{"f":2},{"f":1e+21}]`},
+		{0, `[{"f":0},{"f":2},{"f":1000000000000000000000}]`},
+		{3, `[{"f":0.333},{"f":2.000},{"f":1000000000000000000000.000}]`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := df.WriteJSON(&buf, WriteFloatPrecision(tt.precision)); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(buf.String()); got != tt.want {
+			t.Errorf("precision %d: got %s, want %s", tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestReadJSONOrient(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		orient Orient
+		want   [][]string
+	}{
+		{"records order", `[{"z":1,"a":"x"},{"a":"y","z":2}]`, OrientRecords,
+			[][]string{{"z", "a"}, {"1", "x"}, {"2", "y"}}},
+		{"records missing", `[{"a":1},{"b":2}]`, OrientRecords,
+			[][]string{{"a", "b"}, {"1", "NaN"}, {"NaN", "2"}}},
+		{"columns", `{"z":[1,2],"a":["x",null]}`, OrientColumns,
+			[][]string{{"z", "a"}, {"1", "x"}, {"2", "NaN"}}},
+		{"split", `{"columns":["z","a"],"data":[[1,"x"],[2,"y"]]}`, OrientSplit,
+			[][]string{{"z", "a"}, {"1", "x"}, {"2", "y"}}},
+		{"index", `{"r1":{"z":1,"a":"x"},"r2":{"z":2,"a":"y"}}`, OrientIndex,
+			[][]string{{"z", "a"}, {"1", "x"}, {"2", "y"}}},
+		{"values", `[[1,"x"],[2,"y"]]`, OrientValues,
+			[][]string{{"X0", "X1"}, {"1", "x"}, {"2", "y"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRecords(t, ReadJSON(strings.NewReader(tt.data), WithOrient(tt.orient)), tt.want)
+		})
+	}
+
+	errs := []struct {
+		name   string
+		data   string
+		orient Orient
+	}{
+		{"records not array", `{"a":1}`, OrientRecords},
+		{"columns length", `{"a":[1,2],"b":[1]}`, OrientColumns},
+		{"split row length", `{"columns":["a","b"],"data":[[1]]}`, OrientSplit},
+		{"unknown orient", `[]`, "table"},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if df := ReadJSON(strings.NewReader(tt.data), WithOrient(tt.orient)); df.Err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// JSONLinesReader 按块读取 JSON Lines (NDJSON) 数据，每行一个 JSON 对象，每次返回最多 size 行的 DataFrame。
+// 空行会被忽略。每一块的列由该块中出现的键按首次出现的顺序确定，类型检测规则与 LoadMaps 相同；
+// 已经出现过的列沿用第一次检测到的类型，无法转换为该类型的值会成为 NaN。
+type JSONLinesReader struct {
+	r       *bufio.Reader
+	closer  io.Closer // 释放解压器
+	size    int
+	cfg     loadOptions
+	options []LoadOption
+	types   map[string]series.Type // 已经确定的列类型
+	line    int                    // 已经读取的行数，用于报告出错的行号
+	rows    int                    // 已经读取的记录数，包括被跳过的记录
+	Err     error                  // 错误信息
+}
+
+// NewJSONLinesReader 创建一个每块最多 size 行的 JSONLinesReader，支持与 ReadJSON 相同的 LoadOption。
+// 输入可以是压缩的。SkipRows 和 NRows 作用于整个输入而不是每一块，按记录而不是按行计数。
+func NewJSONLinesReader(r io.Reader, size int, options ...LoadOption) *JSONLinesReader {
+	if size <= 0 {
+		return &JSONLinesReader{
+			closer: io.NopCloser(r),
+			size:   size,
+			Err:    series.NewError(series.ErrInvalidArgument, "NewJSONLinesReader", "positive_chunk_size"),
+		}
+	}
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	dr, closer, err := openInput(r, cfg.encoding)
+	if err != nil {
+		dr, closer = r, io.NopCloser(r)
+	}
+	c := &JSONLinesReader{
+		r:       bufio.NewReader(dr),
+		closer:  closer,
+		size:    size,
+		cfg:     cfg,
+		options: options,
+		types:   make(map[string]series.Type),
+	}
+	if err != nil {
+		c.Err = series.WrapError("NewJSONLinesReader", err)
+	}
+	return c
+}
+
+// Close 释放解压压缩输入时占用的资源，不会关闭传给 NewJSONLinesReader 的输入。
+func (c *JSONLinesReader) Close() error {
+	return c.closer.Close()
+}
+
+// Next 读取并返回下一块数据。没有更多数据时返回 io.EOF，发生其他错误时返回该错误并同时设置 Err。
+// 格式错误的行产生的错误会记录该行的行号。
+func (c *JSONLinesReader) Next() (DataFrame, error) {
+	if c.Err != nil {
+		return DataFrame{Err: c.Err}, c.Err
+	}
+
+	objs, err := c.readObjects(c.size)
+	if err != nil {
+		return c.fail(err)
+	}
+	if len(objs) == 0 {
+		return DataFrame{}, io.EOF
+	}
+
+	options := append(append([]LoadOption(nil), c.options...), SkipRows(0), NRows(0))
+	if len(c.types) > 0 {
+		types := make(map[string]series.Type, len(c.types)+len(c.cfg.types))
+		for colname, t := range c.cfg.types {
+			types[colname] = t
+		}
+		for colname, t := range c.types {
+			types[colname] = t
+		}
+		options = append(options, WithTypes(types))
+	}
+	df := loadObjects(objs, options...)
+	if df.Err != nil {
+		return c.fail(df.Err)
+	}
+	for _, col := range df.columns {
+		if _, ok := c.types[col.Name]; !ok {
+			c.types[col.Name] = col.Type()
+		}
+	}
+	return df, nil
+}
+
+// readObjects 读取最多 n 条记录，n 为负数时读取所有剩余的记录，SkipRows 跳过的记录不计入 n。
+func (c *JSONLinesReader) readObjects(n int) ([]jsonObject, error) {
+	var objs []jsonObject
+	for n < 0 || len(objs) < n {
+		if c.cfg.nrows > 0 && c.rows >= c.cfg.skipRows+c.cfg.nrows {
+			break
+		}
+		o, err := c.readRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.rows++
+		if c.rows > c.cfg.skipRows {
+			objs = append(objs, o)
+		}
+	}
+	return objs, nil
+}
+
+// readRecord 读取下一个非空行并解析为 JSON 对象。
+func (c *JSONLinesReader) readRecord() (jsonObject, error) {
+	for {
+		line, err := c.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return jsonObject{}, err
+		}
+		if len(line) == 0 && err == io.EOF {
+			return jsonObject{}, io.EOF
+		}
+		c.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err == io.EOF {
+				return jsonObject{}, io.EOF
+			}
+			continue
+		}
+
+		var o jsonObject
+		d := json.NewDecoder(bytes.NewReader(line))
+		derr := d.Decode(&o)
+		if derr == nil && d.More() {
+			derr = series.NewError(series.ErrInvalidArgument, "", "json_trailing_data")
+		}
+		if derr == nil && o.values == nil {
+			derr = series.NewError(series.ErrInvalidArgument, "", "json_not_object")
+		}
+		if derr != nil {
+			return jsonObject{}, series.NewError(series.ErrInvalidArgument, "", "json_line", c.line).Wrap(derr)
+		}
+		return o, nil
+	}
+}
+
+// fail 记录错误并返回。
+func (c *JSONLinesReader) fail(err error) (DataFrame, error) {
+	c.Err = series.WrapError("ReadJSONLines", err)
+	return DataFrame{Err: c.Err}, c.Err
+}
+
+// Each 依次对剩余的每一块数据调用 f，f 返回错误时停止读取并返回该错误。
+func (c *JSONLinesReader) Each(f func(DataFrame) error) error {
+	for {
+		df, err := c.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(df); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadJSONLines 从 JSON Lines (NDJSON) 格式的输入读取 DataFrame，每行一个 JSON 对象，空行会被忽略。
+// 列按照键首次出现的顺序排列，类型检测规则与 LoadMaps 相同。需要分块处理大量数据时使用 NewJSONLinesReader。
+func ReadJSONLines(r io.Reader, options ...LoadOption) DataFrame {
+	c := NewJSONLinesReader(r, 1, options...)
+	defer c.Close()
+	if c.Err != nil {
+		return DataFrame{Err: c.Err}
+	}
+
+	objs, err := c.readObjects(-1)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("ReadJSONLines", err)}
+	}
+	df := loadObjects(objs, append(append([]LoadOption(nil), options...), SkipRows(0), NRows(0))...)
+	if df.Err != nil {
+		df.Err = series.WrapError("ReadJSONLines", df.Err)
+	}
+	return df
+}
+
+// WriteJSONLines 将 DataFrame 写入 JSON Lines (NDJSON) 格式，每行一个 JSON 对象，键按照列的顺序输出，
+// NaN 输出为 null。支持 WriteFloatPrecision、WriteEncoding、WriteBOM 和 WriteCompression 选项。
+func (df DataFrame) WriteJSONLines(w io.Writer, options ...WriteOption) error {
+	if df.Err != nil {
+		return df.Err
+	}
+
+	cfg := writeOptions{
+		floatPrecision: -1,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	ow, err := openOutput(w, cfg)
+	if err != nil {
+		return series.WrapError("WriteJSONLines", err)
+	}
+	enc := json.NewEncoder(ow)
+	for i := 0; i < df.nrows; i++ {
+		if err := enc.Encode(df.jsonRecord(i, cfg)); err != nil {
+			ow.Close()
+			return err
+		}
+	}
+	return ow.Close()
+}
@@ -0,0 +1,134 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestReadJSONLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options []LoadOption
+		want    [][]string
+	}{
+		{"basic", "{\"a\":1,\"b\":\"x\"}\n{\"a\":2,\"b\":\"y\"}\n", nil,
+			[][]string{{"a", "b"}, {"1", "x"}, {"2", "y"}}},
+		{"blank lines", "\n{\"a\":1}\n  \n\n{\"a\":2}", nil,
+			[][]string{{"a"}, {"1"}, {"2"}}},
+		{"crlf", "{\"a\":1}\r\n{\"a\":2}\r\n", nil,
+			[][]string{{"a"}, {"1"}, {"2"}}},
+		{"new keys", "{\"a\":1}\n{\"b\":true,\"a\":2}\n", nil,
+			[][]string{{"a", "b"}, {"1", "NaN"}, {"2", "true"}}},
+		{"null", "{\"a\":1}\n{\"a\":null}\n", nil,
+			[][]string{{"a"}, {"1"}, {"NaN"}}},
+		{"skip and limit", "{\"a\":1}\n\n{\"a\":2}\n{\"a\":3}\n{\"a\":4}\n", []LoadOption{SkipRows(1), NRows(2)},
+			[][]string{{"a"}, {"2"}, {"3"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRecords(t, ReadJSONLines(strings.NewReader(tt.data), tt.options...), tt.want)
+		})
+	}
+}
+
+func TestReadJSONLinesErrors(t *testing.T) {
+	defer series.SetLocale("zh")
+	series.SetLocale("en")
+	tests := []struct {
+		name string
+		data string
+		msg  string
+	}{
+		{"malformed", "{\"a\":1}\n\n{\"a\":\n", "line 3 "},
+		{"array", "{\"a\":1}\n[1,2]\n", "line 2 "},
+		{"trailing data", "{\"a\":1} {\"a\":2}\n", "line 1 "},
+		{"scalar", "1\n", "line 1 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := ReadJSONLines(strings.NewReader(tt.data))
+			if !errors.Is(df.Err, ErrInvalidArgument) {
+				t.Fatalf("err = %v, want %v", df.Err, ErrInvalidArgument)
+			}
+			if !strings.Contains(df.Err.Error(), tt.msg) {
+				t.Errorf("err = %v, want it to contain %q", df.Err, tt.msg)
+			}
+		})
+	}
+}
+
+func TestJSONLinesReader(t *testing.T) {
+	const data = "{\"a\":1}\n{\"a\":2}\n\n{\"a\":\"x\",\"b\":1.5}\n{\"a\":4}\n{\"a\":5}\n"
+	c := NewJSONLinesReader(strings.NewReader(data), 2)
+	defer c.Close()
+	var got [][][]string
+	if err := c.Each(func(df DataFrame) error {
+		got = append(got, df.Records())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := [][][]string{
+		{{"a"}, {"1"}, {"2"}},
+		{{"a", "b"}, {"NaN", "1.500000"}, {"4", "NaN"}},
+		{{"a"}, {"5"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("chunks = %v, want %v", got, want)
+	}
+	if _, err := c.Next(); err != io.EOF {
+		t.Errorf("Next after end = %v, want io.EOF", err)
+	}
+
+	for _, size := range []int{0, -1} {
+		c := NewJSONLinesReader(strings.NewReader(data), size)
+		if _, err := c.Next(); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("size %d: err = %v, want %v", size, err, ErrInvalidArgument)
+		}
+	}
+
+	c = NewJSONLinesReader(strings.NewReader("{\"a\":1}\n{\"a\":2}\nx\n"), 2)
+	if _, err := c.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Next(); err == nil || c.Err != err {
+		t.Errorf("malformed chunk: err = %v, Err = %v", err, c.Err)
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	df := New(
+		series.New([]string{"x", "y"}, series.String, "b"),
+		series.New([]float64{1.25, 0}, series.Float, "a"),
+		series.New([]interface{}{1, nil}, series.Int, "c"),
+	)
+	tests := []struct {
+		name    string
+		options []WriteOption
+		want    string
+	}{
+		{"default", nil, "{\"b\":\"x\",\"a\":1.25,\"c\":1}\n{\"b\":\"y\",\"a\":0,\"c\":null}\n"},
+		{"precision", []WriteOption{WriteFloatPrecision(1)}, "{\"b\":\"x\",\"a\":1.2,\"c\":1}\n{\"b\":\"y\",\"a\":0.0,\"c\":null}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := df.WriteJSONLines(&buf, tt.options...); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+			if tt.options == nil {
+				checkRecords(t, ReadJSONLines(&buf), df.Records())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestLoadMaps(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		maps    []map[string]interface{}
+		options []LoadOption
+		want    [][]string
+		types   []series.Type
+	}{
+		{"sorted columns",
+			[]map[string]interface{}{{"b": 1, "a": "x"}, {"a": "y", "b": 2}}, nil,
+			[][]string{{"a", "b"}, {"x", "1"}, {"y", "2"}},
+			[]series.Type{series.String, series.Int}},
+		{"column order",
+			[]map[string]interface{}{{"b": 1, "a": "x", "c": true}}, []LoadOption{ColumnOrder("c", "b")},
+			[][]string{{"c", "b", "a"}, {"true", "1", "x"}},
+			[]series.Type{series.Bool, series.Int, series.String}},
+		{"missing and nil",
+			[]map[string]interface{}{{"a": 1}, {"a": nil, "b": "y"}}, nil,
+			[][]string{{"a", "b"}, {"1", ""}, {"NaN", "y"}},
+			[]series.Type{series.Int, series.String}},
+		{"mixed numbers",
+			[]map[string]interface{}{{"a": 1}, {"a": 2.5}, {"a": int64(3)}}, nil,
+			[][]string{{"a"}, {"1.000000"}, {"2.500000"}, {"3.000000"}},
+			[]series.Type{series.Float}},
+		{"native bools",
+			[]map[string]interface{}{{"a": true}, {"a": false}}, nil,
+			[][]string{{"a"}, {"true"}, {"false"}},
+			[]series.Type{series.Bool}},
+		{"time",
+			[]map[string]interface{}{{"t": ts}}, nil,
+			[][]string{{"t"}, {"2024-01-02T03:04:05Z"}},
+			[]series.Type{series.String}},
+		{"with types",
+			[]map[string]interface{}{{"a": 1}, {"a": 2}}, []LoadOption{WithTypes(map[string]series.Type{"a": series.String})},
+			[][]string{{"a"}, {"1"}, {"2"}},
+			[]series.Type{series.String}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := LoadMaps(tt.maps, tt.options...)
+			checkRecords(t, df, tt.want)
+			if got := df.Types(); !reflect.DeepEqual(got, tt.types) {
+				t.Errorf("types = %v, want %v", got, tt.types)
+			}
+		})
+	}
+}
+
+func TestLoadMapsNativeValues(t *testing.T) {
+	const big = 1<<53 + 1
+	df := LoadMaps([]map[string]interface{}{{"i": big, "f": 0.1 + 0.2}})
+	if df.Err != nil {
+		t.Fatal(df.Err)
+	}
+	if got, err := df.Col("i").Elem(0).Int(); err != nil || got != big {
+		t.Errorf("i = %v (%v), want %v", got, err, big)
+	}
+	if got := df.Col("f").Elem(0).Float(); got != 0.1+0.2 {
+		t.Errorf("f = %v, want %v", got, 0.1+0.2)
+	}
+
+	if df := LoadMaps(nil); df.Err == nil {
+		t.Error("empty maps: expected error")
+	}
+}
@@ -0,0 +1,281 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"stream/go-sdk/test/gota_study/series"
+	"strings"
+)
+
+// RecordPath 函数返回一个LoadOption，用于设置 JSONNormalize 展开为行的嵌套记录数组的路径，
+// 各层的键以 "." 连接，数组可以用 "[]" 标明，例如 "orders[].items[]" 与 "orders.items" 相同。
+func RecordPath(path string) LoadOption {
+	return func(c *loadOptions) {
+		c.recordPath = path
+	}
+}
+
+// MetaFields 函数返回一个LoadOption，用于设置 JSONNormalize 附加到每一行的上层对象字段。
+// 字段以 "." 连接的路径表示，与记录路径开头相同的部分确定字段所在的层，例如记录路径为
+// "orders.items" 时，"id" 表示顶层对象的 id 字段，"orders.date" 表示 orders 数组元素的 date 字段。
+func MetaFields(fields ...string) LoadOption {
+	return func(c *loadOptions) {
+		c.metaFields = fields
+	}
+}
+
+// MetaPrefix 函数返回一个LoadOption，用于设置 JSONNormalize 附加列的列名前缀，例如前缀为 "meta."
+// 时附加字段 id 的列名为 meta.id。附加列与记录的列同名时 JSONNormalize 返回错误，需要设置前缀加以区分。
+func MetaPrefix(prefix string) LoadOption {
+	return func(c *loadOptions) {
+		c.metaPrefix = prefix
+	}
+}
+
+// MaxDepth 函数返回一个LoadOption，用于设置 JSONNormalize 展平嵌套对象的最大层数，
+// 更深的对象以 JSON 文本保存。0 表示不展平，负数表示不限制，默认不限制。
+func MaxDepth(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.maxDepth = n
+	}
+}
+
+// JSONNormalize 从 JSON 输入读取嵌套的数据并展平为 DataFrame，输入可以是对象数组或单个对象。
+// 嵌套对象的字段展平为以 "." 连接的列名，例如 {"user": {"name": "a"}} 得到列 user.name；
+// 数组和超过 MaxDepth 的对象以 JSON 文本保存。使用 RecordPath 可以将嵌套的记录数组展开为行，
+// 使用 MetaFields 将上层对象的字段附加到每一行，附加的列位于记录的列之后，列名可以用 MetaPrefix 加上前缀。
+// 缺少的字段和 null 加载为 NaN，类型检测规则与 LoadMaps 相同。
+func JSONNormalize(r io.Reader, options ...LoadOption) DataFrame {
+	cfg := loadOptions{
+		maxDepth: -1,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	r, closer, err := openInput(r, cfg.encoding)
+	if err != nil {
+		return DataFrame{Err: series.WrapError("JSONNormalize", err)}
+	}
+	defer closer.Close()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return DataFrame{Err: series.WrapError("JSONNormalize", err)}
+	}
+	var tops []json.RawMessage
+	switch jsonKind(raw) {
+	case '[':
+		if err := json.Unmarshal(raw, &tops); err != nil {
+			return DataFrame{Err: series.WrapError("JSONNormalize", err)}
+		}
+	case '{':
+		tops = []json.RawMessage{raw}
+	default:
+		return DataFrame{Err: series.NewError(series.ErrInvalidArgument, "JSONNormalize", "json_not_object")}
+	}
+
+	n := newNormalizer(cfg)
+	for _, top := range tops {
+		if err := n.walk(top, 0, nil); err != nil {
+			return DataFrame{Err: series.WrapError("JSONNormalize", err)}
+		}
+	}
+	colnames, err := n.colnames()
+	if err != nil {
+		return DataFrame{Err: series.WrapError("JSONNormalize", err)}
+	}
+	df := loadRows(colnames, n.table(), options...)
+	if df.Err != nil {
+		df.Err = series.WrapError("JSONNormalize", df.Err)
+	}
+	return df
+}
+
+// jsonKind 返回 JSON 值的第一个非空白字符。
+func jsonKind(raw json.RawMessage) byte {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return 0
+	}
+	return raw[0]
+}
+
+// normalizer 保存 JSONNormalize 展平得到的行和列。
+type normalizer struct {
+	maxDepth int
+	prefix   string     // 附加列的列名前缀
+	path     []string   // 记录路径的各层键
+	meta     [][]string // 附加字段的路径
+	names    []string   // 记录的列名，按首次出现的顺序排列
+	seen     map[string]bool
+	rows     []normalizedRow
+}
+
+// normalizedRow 是展平后的一行。
+type normalizedRow struct {
+	fields map[string]interface{} // 记录的字段
+	meta   []interface{}          // 附加字段的值，与 normalizer.meta 对应
+}
+
+// newNormalizer 根据加载选项创建 normalizer。
+func newNormalizer(cfg loadOptions) *normalizer {
+	n := &normalizer{maxDepth: cfg.maxDepth, prefix: cfg.metaPrefix, seen: make(map[string]bool)}
+	if cfg.recordPath != "" {
+		for _, key := range strings.Split(cfg.recordPath, ".") {
+			n.path = append(n.path, strings.TrimSuffix(key, "[]"))
+		}
+	}
+	for _, field := range cfg.metaFields {
+		n.meta = append(n.meta, strings.Split(field, "."))
+	}
+	return n
+}
+
+// walk 沿记录路径访问第 level 层的对象 raw，parents 为上层的对象。到达记录时展平为一行。
+// 记录路径上缺少的键或 null 值不产生行。
+func (n *normalizer) walk(raw json.RawMessage, level int, parents []jsonObject) error {
+	var obj jsonObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return err
+	}
+	parents = append(parents[:level:level], obj)
+	if level == len(n.path) {
+		return n.addRow(obj, parents)
+	}
+
+	next, ok := obj.values[n.path[level]]
+	if !ok {
+		return nil
+	}
+	switch jsonKind(next) {
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(next, &elems); err != nil {
+			return err
+		}
+		for _, elem := range elems {
+			if err := n.walk(elem, level+1, parents); err != nil {
+				return err
+			}
+		}
+	case '{':
+		return n.walk(next, level+1, parents)
+	}
+	return nil
+}
+
+// addRow 将记录 obj 展平为一行，并附加上层对象的字段。
+func (n *normalizer) addRow(obj jsonObject, parents []jsonObject) error {
+	row := normalizedRow{fields: make(map[string]interface{}), meta: make([]interface{}, len(n.meta))}
+	if err := n.flatten(obj, "", 0, row.fields); err != nil {
+		return err
+	}
+	for i, field := range n.meta {
+		v, err := n.metaValue(field, parents)
+		if err != nil {
+			return err
+		}
+		row.meta[i] = v
+	}
+	n.rows = append(n.rows, row)
+	return nil
+}
+
+// flatten 将对象 obj 的字段以 prefix 为前缀写入 row，depth 为 obj 的嵌套层数。
+func (n *normalizer) flatten(obj jsonObject, prefix string, depth int, row map[string]interface{}) error {
+	for _, k := range obj.keys {
+		name := prefix + k
+		raw := obj.values[k]
+		if jsonKind(raw) == '{' && (n.maxDepth < 0 || depth < n.maxDepth) {
+			var child jsonObject
+			if err := json.Unmarshal(raw, &child); err != nil {
+				return err
+			}
+			if err := n.flatten(child, name+".", depth+1, row); err != nil {
+				return err
+			}
+			continue
+		}
+		v, err := leafValue(raw)
+		if err != nil {
+			return err
+		}
+		row[name] = v
+		if !n.seen[name] {
+			n.seen[name] = true
+			n.names = append(n.names, name)
+		}
+	}
+	return nil
+}
+
+// metaValue 返回附加字段 field 的值。field 与记录路径开头相同的部分确定所在的层，其余部分在该层的对象中查找。
+func (n *normalizer) metaValue(field []string, parents []jsonObject) (interface{}, error) {
+	level := 0
+	for level < len(n.path) && level < len(field)-1 && field[level] == n.path[level] {
+		level++
+	}
+	obj := parents[level]
+	for i := level; i < len(field); i++ {
+		raw, ok := obj.values[field[i]]
+		if !ok {
+			return nil, nil
+		}
+		if i == len(field)-1 {
+			return leafValue(raw)
+		}
+		if jsonKind(raw) != '{' {
+			return nil, nil
+		}
+		obj = jsonObject{}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// leafValue 返回不再展平的值，对象和数组返回紧凑的 JSON 文本。
+func leafValue(raw json.RawMessage) (interface{}, error) {
+	switch jsonKind(raw) {
+	case '{', '[':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+	return decodeJSONValue(raw)
+}
+
+// colnames 返回所有列名，附加字段的列位于记录的列之后。附加列与其他列同名时返回错误。
+func (n *normalizer) colnames() ([]string, error) {
+	colnames := append([]string(nil), n.names...)
+	seen := make(map[string]bool, len(n.names)+len(n.meta))
+	for _, name := range n.names {
+		seen[name] = true
+	}
+	for _, field := range n.meta {
+		name := n.prefix + strings.Join(field, ".")
+		if seen[name] {
+			return nil, series.NewError(series.ErrInvalidArgument, "", "meta_column_conflict", name)
+		}
+		seen[name] = true
+		colnames = append(colnames, name)
+	}
+	return colnames, nil
+}
+
+// table 返回按照 colnames 排列的行，缺少的字段为 nil。
+func (n *normalizer) table() [][]interface{} {
+	rows := make([][]interface{}, len(n.rows))
+	for i, r := range n.rows {
+		row := make([]interface{}, 0, len(n.names)+len(n.meta))
+		for _, name := range n.names {
+			row = append(row, r.fields[name])
+		}
+		rows[i] = append(row, r.meta...)
+	}
+	return rows
+}
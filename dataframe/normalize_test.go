@@ -0,0 +1,90 @@
+package dataframe
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONNormalize(t *testing.T) {
+	const orders = `[
+		{"id": 1, "user": {"name": "a", "addr": {"city": "x"}}, "orders": [
+			{"date": "d1", "items": [{"sku": "s1", "qty": 2}, {"sku": "s2", "qty": 1}]},
+			{"date": "d2", "items": []}
+		]},
+		{"id": 2, "user": {"name": "b"}, "orders": [
+			{"date": "d3", "items": [{"sku": "s3", "qty": 5}]}
+		]},
+		{"id": 3, "orders": null}
+	]`
+	tests := []struct {
+		name    string
+		data    string
+		options []LoadOption
+		want    [][]string
+	}{
+		{"flatten", `[{"a": 1, "b": {"c": "x", "d": {"e": true}}}, {"a": 2, "f": [1, 2]}]`, nil,
+			[][]string{{"a", "b.c", "b.d.e", "f"}, {"1", "x", "true", "NaN"}, {"2", "NaN", "NaN", "[1,2]"}}},
+		{"single object", `{"a": 1, "b": {"c": null}}`, nil,
+			[][]string{{"a", "b.c"}, {"1", "NaN"}}},
+		{"max depth", `[{"a": {"b": {"c": 1}}}]`, []LoadOption{MaxDepth(1)},
+			[][]string{{"a.b"}, {`{"c":1}`}}},
+		{"max depth zero", `[{"a": {"b": 1}}]`, []LoadOption{MaxDepth(0)},
+			[][]string{{"a"}, {`{"b":1}`}}},
+		{"record path", orders, []LoadOption{RecordPath("orders[].items[]"), MetaFields("id", "user.name", "orders.date")},
+			[][]string{
+				{"sku", "qty", "id", "user.name", "orders.date"},
+				{"s1", "2", "1", "a", "d1"},
+				{"s2", "1", "1", "a", "d1"},
+				{"s3", "5", "2", "b", "d3"},
+			}},
+		{"record path without brackets", orders, []LoadOption{RecordPath("orders"), MetaFields("id")},
+			[][]string{
+				{"date", "items", "id"},
+				{"d1", `[{"sku":"s1","qty":2},{"sku":"s2","qty":1}]`, "1"},
+				{"d2", "[]", "1"},
+				{"d3", `[{"sku":"s3","qty":5}]`, "2"},
+			}},
+		{"missing meta", orders, []LoadOption{RecordPath("orders"), MetaFields("user.addr.city")},
+			[][]string{
+				{"date", "items", "user.addr.city"},
+				{"d1", `[{"sku":"s1","qty":2},{"sku":"s2","qty":1}]`, "x"},
+				{"d2", "[]", "x"},
+				{"d3", `[{"sku":"s3","qty":5}]`, "NaN"},
+			}},
+		{"meta prefix", `[{"id": 1, "rows": [{"id": 10}, {"id": 11}]}]`,
+			[]LoadOption{RecordPath("rows"), MetaFields("id"), MetaPrefix("parent.")},
+			[][]string{{"id", "parent.id"}, {"10", "1"}, {"11", "1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkRecords(t, JSONNormalize(strings.NewReader(tt.data), tt.options...), tt.want)
+		})
+	}
+}
+
+func TestJSONNormalizeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options []LoadOption
+	}{
+		{"meta conflict", `[{"id": 1, "rows": [{"id": 10}]}]`, []LoadOption{RecordPath("rows"), MetaFields("id")}},
+		{"meta conflict with prefix", `[{"id": 1, "rows": [{"p.id": 10}]}]`,
+			[]LoadOption{RecordPath("rows"), MetaFields("id"), MetaPrefix("p.")}},
+		{"duplicate meta", `[{"id": 1, "rows": [{"x": 10}]}]`, []LoadOption{RecordPath("rows"), MetaFields("id", "id")}},
+		{"scalar", `1`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := JSONNormalize(strings.NewReader(tt.data), tt.options...)
+			if !errors.Is(df.Err, ErrInvalidArgument) {
+				t.Errorf("err = %v, want %v", df.Err, ErrInvalidArgument)
+			}
+		})
+	}
+
+	if df := JSONNormalize(strings.NewReader(`[{"a": 1}`)); df.Err == nil {
+		t.Error("malformed JSON: expected error")
+	}
+}
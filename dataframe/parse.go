@@ -0,0 +1,354 @@
+package dataframe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"stream/go-sdk/test/gota_study/series"
+	"strings"
+	"time"
+)
+
+// Converter 是将字段转换为列元素值的函数，返回值的类型应为 string、int、float64 或 bool，返回 nil 表示 NaN。
+// NaN 字段不会传给 Converter。
+type Converter func(string) interface{}
+
+// RowError 描述加载时字段数与表头不一致的一行。
+type RowError struct {
+	Row      int // 数据行的索引，从 0 开始，不包括表头
+	Fields   int // 该行的字段数
+	Expected int // 表头的字段数
+}
+
+// Error 返回 RowError 的描述。
+func (e RowError) Error() string {
+	return series.Message("ragged_row", e.Row, e.Fields, e.Expected)
+}
+
+// fixRaggedRows 检查每条记录的字段数是否为 n。允许不一致时补齐缺少的字段并丢弃多余的字段，
+// 否则返回错误。原始记录不会被修改。
+func fixRaggedRows(records [][]string, n int, cfg loadOptions) ([][]string, error) {
+	var fixed [][]string
+	for j, record := range records {
+		if len(record) == n {
+			continue
+		}
+		if !cfg.ragged {
+			return nil, series.NewError(series.ErrDimensionMismatch, "", "ragged_row", j, len(record), n)
+		}
+		if fixed == nil {
+			fixed = append([][]string(nil), records...)
+		}
+		row := make([]string, n)
+		copy(row, record)
+		for k := len(record); k < n; k++ {
+			row[k] = "NaN"
+		}
+		fixed[j] = row
+		if cfg.raggedRows != nil {
+			*cfg.raggedRows = append(*cfg.raggedRows, RowError{Row: j, Fields: len(record), Expected: n})
+		}
+	}
+	if fixed == nil {
+		return records, nil
+	}
+	return fixed, nil
+}
+
+// parseColumn 按照加载选项把字段解析为列。千位分隔符、小数逗号和布尔值词汇只在列被解析为
+// 数字或布尔类型时生效，字符串列保留原始字段。
+func parseColumn(rawcol []string, colname string, cfg loadOptions) series.Series {
+	norm := normalizeColumn(rawcol, cfg)
+	detect := rawcol
+	if norm != nil {
+		detect = norm
+	}
+	t, ok := cfg.types[colname]
+	if !ok {
+		t = cfg.defaultType
+		if cfg.detectTypes {
+			if l, err := findType(detect); err == nil {
+				t = l
+			}
+		}
+	}
+	values := rawcol
+	if norm != nil && t != series.String && t != series.Categorical {
+		values = norm
+	}
+	return series.New(values, t, colname)
+}
+
+// normalizeColumn 将字段中的数字和布尔值转换为标准格式，没有相关选项时返回 nil。
+func normalizeColumn(rawcol []string, cfg loadOptions) []string {
+	if cfg.thousands == 0 && cfg.decimal == 0 && cfg.trueValues == nil && cfg.falseValues == nil {
+		return nil
+	}
+	norm := make([]string, len(rawcol))
+	for j, v := range rawcol {
+		switch {
+		case v == "NaN":
+		case findInStringSlice(v, cfg.trueValues) != -1:
+			v = "true"
+		case findInStringSlice(v, cfg.falseValues) != -1:
+			v = "false"
+		default:
+			if cfg.thousands != 0 {
+				v = strings.ReplaceAll(v, string(cfg.thousands), "")
+			}
+			if cfg.decimal != 0 && cfg.decimal != '.' {
+				v = strings.ReplaceAll(v, string(cfg.decimal), ".")
+			}
+		}
+		norm[j] = v
+	}
+	return norm
+}
+
+// convertColumn 使用 Converter 转换字段，列类型由 WithTypes 指定或根据转换结果确定。
+func convertColumn(rawcol []string, convert Converter, colname string, cfg loadOptions) series.Series {
+	values := make([]interface{}, len(rawcol))
+	var hasStrings, hasBools, hasFloats, hasInts bool
+	for j, v := range rawcol {
+		if v == "NaN" {
+			continue
+		}
+		values[j] = convert(v)
+		switch values[j].(type) {
+		case nil:
+		case int:
+			hasInts = true
+		case float64:
+			hasFloats = true
+		case bool:
+			hasBools = true
+		default:
+			hasStrings = true
+		}
+	}
+	t, ok := cfg.types[colname]
+	if !ok {
+		switch {
+		case hasStrings:
+			t = series.String
+		case hasBools:
+			t = series.Bool
+		case hasFloats:
+			t = series.Float
+		case hasInts:
+			t = series.Int
+		default:
+			t = cfg.defaultType
+		}
+	}
+	return series.New(values, t, colname)
+}
+
+// missingValue 表示 map 或 JSON 对象中不存在的键，与空字符串一样加载。
+type missingValue struct{}
+
+// loadRows 按照列名和行加载 DataFrame，支持 LoadRecords 中除 HasHeader 和 AllowRaggedRows 外的选项。
+// 只包含整数、浮点数或布尔值的列直接使用这些值创建，其他列与 LoadRecords 一样转换为字符串后解析。
+// nil 加载为 NaN。
+func loadRows(colnames []string, rows [][]interface{}, options ...LoadOption) DataFrame {
+	cfg := loadOptions{
+		defaultType: series.String,
+		detectTypes: true,
+		nanValues:   []string{"NA", "NaN", "<nil>"},
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if cfg.skipRows > 0 {
+		if cfg.skipRows >= len(rows) {
+			rows = nil
+		} else {
+			rows = rows[cfg.skipRows:]
+		}
+	}
+	if cfg.nrows > 0 && len(rows) > cfg.nrows {
+		rows = rows[:cfg.nrows]
+	}
+	if len(rows) == 0 {
+		return DataFrame{Err: series.NewError(series.ErrEmpty, "", "empty_array")}
+	}
+	headers := colnames
+	if cfg.names != nil {
+		if len(cfg.names) != len(colnames) {
+			if len(cfg.names) > len(colnames) {
+				return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "", "too_many_names")}
+			}
+			return DataFrame{Err: series.NewError(series.ErrDimensionMismatch, "", "too_few_names")}
+		}
+		headers = cfg.names
+	}
+	if cfg.trimSpace {
+		trimmed := make([]string, len(headers))
+		for i, h := range headers {
+			trimmed[i] = strings.TrimSpace(h)
+		}
+		headers = trimmed
+	}
+
+	usecols := make([]int, len(headers))
+	for i := range usecols {
+		usecols[i] = i
+	}
+	if cfg.useColumns != nil {
+		var err error
+		usecols, err = parseSelectIndexes(len(headers), cfg.useColumns, headers)
+		if err != nil {
+			return DataFrame{Err: err}
+		}
+		for _, i := range usecols {
+			if i < 0 || i >= len(headers) {
+				return DataFrame{Err: series.NewError(series.ErrIndexOutOfRange, "", "")}
+			}
+		}
+	}
+
+	columns := make([]series.Series, len(usecols))
+	for k, i := range usecols {
+		colname := headers[i]
+		values := make([]interface{}, len(rows))
+		for j, row := range rows {
+			if i < len(row) {
+				values[j] = nativeValue(row[i])
+			}
+		}
+		columns[k] = nativeColumn(values, colname, cfg)
+		if columns[k].Err != nil {
+			return DataFrame{Err: columnError("", colname, columns[k].Err)}
+		}
+	}
+	df := DataFrame{
+		columns: columns,
+		ncols:   len(columns),
+		nrows:   len(rows),
+	}
+	colnames = df.Names()
+	fixColnames(colnames)
+	for i, colname := range colnames {
+		df.columns[i].Name = colname
+	}
+	return df
+}
+
+// nativeValue 将值转换为 int、float64、bool、string、nil 或 missingValue，其他类型的值使用 fmt.Sprint 转换为字符串，
+// time.Time 转换为 RFC 3339 格式的字符串，超出 int 范围的整数转换为十进制字符串。
+func nativeValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil, missingValue, int, float64, bool, string:
+		return x
+	case json.Number:
+		return string(x)
+	case int8:
+		return int(x)
+	case int16:
+		return int(x)
+	case int32:
+		return int(x)
+	case int64:
+		return nativeInt(x)
+	case uint:
+		return nativeUint(uint64(x))
+	case uint8:
+		return int(x)
+	case uint16:
+		return int(x)
+	case uint32:
+		return nativeUint(uint64(x))
+	case uint64:
+		return nativeUint(x)
+	case float32:
+		return float64(x)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// nativeInt 将 int64 转换为 int，超出 int 范围时(int 为 32 位的平台上)转换为十进制字符串，避免截断。
+func nativeInt(x int64) interface{} {
+	if x > math.MaxInt || x < math.MinInt {
+		return strconv.FormatInt(x, 10)
+	}
+	return int(x)
+}
+
+// nativeUint 将无符号整数转换为 int，超出 int 范围时转换为十进制字符串，避免回绕为负数。
+func nativeUint(x uint64) interface{} {
+	if x > math.MaxInt {
+		return strconv.FormatUint(x, 10)
+	}
+	return int(x)
+}
+
+// nativeColumn 使用 nativeValue 转换后的值创建列。只包含整数和浮点数或只包含布尔值、且没有 Converter 的列
+// 直接使用这些值创建 Int、Float 或 Bool 列，类型由 WithTypes 指定或根据值确定，缺少的值为 NaN；
+// 其他列转换为字符串，按照与 LoadRecords 相同的规则解析，缺少的值为空字符串。
+func nativeColumn(values []interface{}, colname string, cfg loadOptions) series.Series {
+	var hasStrings, hasBools, hasFloats, hasInts bool
+	for _, v := range values {
+		switch v.(type) {
+		case nil, missingValue:
+		case int:
+			hasInts = true
+		case float64:
+			hasFloats = true
+		case bool:
+			hasBools = true
+		default:
+			hasStrings = true
+		}
+	}
+	var t series.Type
+	switch {
+	case hasStrings || hasBools == (hasInts || hasFloats):
+	case hasBools:
+		t = series.Bool
+	case hasFloats:
+		t = series.Float
+	default:
+		t = series.Int
+	}
+	if ct, ok := cfg.types[colname]; ok {
+		t = ct
+	} else if !cfg.detectTypes {
+		t = cfg.defaultType
+	}
+	_, convert := cfg.converters[colname]
+	if !hasStrings && !convert && (t == series.Int || t == series.Float || t == series.Bool) {
+		for j, v := range values {
+			if _, ok := v.(missingValue); ok {
+				values[j] = nil
+			}
+		}
+		return series.New(values, t, colname)
+	}
+
+	rawcol := make([]string, len(values))
+	for j, v := range values {
+		switch v.(type) {
+		case nil:
+			rawcol[j] = "NaN"
+			continue
+		case missingValue:
+			rawcol[j] = ""
+		default:
+			rawcol[j] = fmt.Sprint(v)
+		}
+		if cfg.trimSpace {
+			rawcol[j] = strings.TrimSpace(rawcol[j])
+		}
+		if findInStringSlice(rawcol[j], cfg.nanValues) != -1 {
+			rawcol[j] = "NaN"
+		}
+	}
+	if convert {
+		return convertColumn(rawcol, cfg.converters[colname], colname, cfg)
+	}
+	return parseColumn(rawcol, colname, cfg)
+}
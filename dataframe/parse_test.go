@@ -0,0 +1,73 @@
+package dataframe
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestReadCSVOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options []LoadOption
+		want    [][]string
+		types   []series.Type
+	}{
+		{"skip rows", "report,2024\nunits,kg\na,b\n1,2\n", []LoadOption{SkipRows(2)},
+			[][]string{{"a", "b"}, {"1", "2"}}, []series.Type{series.Int, series.Int}},
+		{"nrows", "a\n1\n2\n3\n", []LoadOption{NRows(2)},
+			[][]string{{"a"}, {"1"}, {"2"}}, []series.Type{series.Int}},
+		{"use columns by name", "a,b,c\n1,x,2.5\n", []LoadOption{UseColumns([]string{"c", "a"})},
+			[][]string{{"c", "a"}, {"2.500000", "1"}}, []series.Type{series.Float, series.Int}},
+		{"use columns by index", "a,b,c\n1,x,2.5\n", []LoadOption{UseColumns([]int{1})},
+			[][]string{{"b"}, {"x"}}, []series.Type{series.String}},
+		{"thousands", "a\n\"1,234\"\n\"12,345,678\"\n", []LoadOption{Thousands(',')},
+			[][]string{{"a"}, {"1234"}, {"12345678"}}, []series.Type{series.Int}},
+		{"thousands not numeric", "a\n\"1,2x\"\n", []LoadOption{Thousands(',')},
+			[][]string{{"a"}, {"1,2x"}}, []series.Type{series.String}},
+		{"decimal comma", "a;b\n1.234,5;3,25\n", []LoadOption{WithDelimiter(';'), Thousands('.'), DecimalComma(true)},
+			[][]string{{"a", "b"}, {"1234.500000", "3.250000"}}, []series.Type{series.Float, series.Float}},
+		{"bool values", "a\nyes\nno\n", []LoadOption{BoolValues([]string{"yes"}, []string{"no"})},
+			[][]string{{"a"}, {"true"}, {"false"}}, []series.Type{series.Bool}},
+		{"trim space", "a,b\n 1 , x \n", []LoadOption{TrimSpace(true)},
+			[][]string{{"a", "b"}, {"1", "x"}}, []series.Type{series.Int, series.String}},
+		{"no trim space", "a,b\n 1 , x \n", nil,
+			[][]string{{"a", "b"}, {" 1 ", " x "}}, []series.Type{series.String, series.String}},
+		{"converters", "a,b\n1,2\n", []LoadOption{WithConverters(map[string]Converter{
+			"a": func(s string) interface{} { return s + "!" },
+		})},
+			[][]string{{"a", "b"}, {"1!", "2"}}, []series.Type{series.String, series.Int}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := ReadCSV(strings.NewReader(tt.data), tt.options...)
+			checkRecords(t, df, tt.want)
+			if got := df.Types(); !reflect.DeepEqual(got, tt.types) {
+				t.Errorf("types = %v, want %v", got, tt.types)
+			}
+		})
+	}
+}
+
+func TestAllowRaggedRows(t *testing.T) {
+	const data = "a,b,c\n1,2,3\n4,5\n6,7,8,9\n"
+	if df := ReadCSV(strings.NewReader(data)); df.Err == nil {
+		t.Error("expected error without AllowRaggedRows")
+	}
+
+	var report []RowError
+	df := ReadCSV(strings.NewReader(data), AllowRaggedRows(&report))
+	checkRecords(t, df, [][]string{{"a", "b", "c"}, {"1", "2", "3"}, {"4", "5", "NaN"}, {"6", "7", "8"}})
+	want := []RowError{{Row: 1, Fields: 2, Expected: 3}, {Row: 2, Fields: 4, Expected: 3}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %v, want %v", report, want)
+	}
+
+	df = ReadCSV(strings.NewReader(data), AllowRaggedRows(nil))
+	if df.Err != nil {
+		t.Errorf("nil report: %v", df.Err)
+	}
+}
@@ -0,0 +1,435 @@
+package dataframe
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"math"
+	"reflect"
+	"stream/go-sdk/test/gota_study/series"
+	"strings"
+	"time"
+)
+
+// Marshaler 由可以表示为 DataFrame 元素的自定义类型实现。LoadStructs 将这些类型的字段加载为
+// MarshalElement 返回的字符串。
+type Marshaler interface {
+	MarshalElement() (string, error)
+}
+
+// Unmarshaler 由可以从 DataFrame 元素解析的自定义类型实现。ToStructs 使用元素的字符串表示调用
+// UnmarshalElement，NaN 元素写入零值而不调用该方法。
+type Unmarshaler interface {
+	UnmarshalElement(string) error
+}
+
+// structTag 解析结构体字段的 dataframe 标签，返回列名和标签中指定的类型名，没有指定类型时 typ 为空。
+// 标签为 "-" 时 skip 为 true。
+func structTag(field reflect.StructField) (name, typ string, skip bool, err error) {
+	name = field.Name
+	tag := field.Tag.Get("dataframe")
+	if tag == "-" {
+		return "", "", true, nil
+	}
+	// 类型中可以包含逗号，例如 "decimal(10,2)"
+	opts := strings.SplitN(tag, ",", 2)
+	if len(opts) == 2 && strings.Contains(opts[1], ",") && !series.IsDecimal(series.Type(strings.TrimSpace(opts[1]))) {
+		return "", "", false, series.NewError(series.ErrInvalidArgument, "", "struct_tag", field.Name, tag)
+	}
+	if n := strings.TrimSpace(opts[0]); n != "" {
+		name = n
+	}
+	if len(opts) == 2 {
+		if t := strings.TrimSpace(opts[1]); t != "" {
+			typ = t
+		}
+	}
+	return name, typ, false, nil
+}
+
+// ToStructs 将 DataFrame 的每一行写入 ptr 指向的切片，ptr 的类型必须是 *[]T 或 *[]*T，T 为结构体。
+// 字段与列的对应规则与 LoadStructs 相同：使用 dataframe 标签中的列名，没有标签时使用字段名，
+// 嵌入和嵌套的结构体按照相同的规则展开，标签为 "-" 的字段和未导出的字段被忽略。
+// 每个字段都必须有对应的列，没有对应字段的列被忽略。
+//
+// 元素按照字段的类型转换：整数列和没有小数部分的浮点数列可以写入整数字段，数字和 "true"、"false"
+// 可以写入布尔字段，实现了 Unmarshaler 或 encoding.TextUnmarshaler 的字段（例如 time.Time）
+// 使用元素的字符串表示解析，实现了 sql.Scanner 的字段（例如 sql.NullInt64）使用元素的值，
+// interface{} 字段得到元素的值。NaN 写入字段类型的零值，指针字段为 nil；嵌套的结构体指针总是被分配。
+// 无法转换的元素会返回错误，错误中记录了列名。
+func (df DataFrame) ToStructs(ptr interface{}) error {
+	if df.Err != nil {
+		return df.Err
+	}
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return series.NewError(series.ErrInvalidArgument, "ToStructs", "struct_target", reflect.TypeOf(ptr))
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return series.NewError(series.ErrInvalidArgument, "ToStructs", "struct_target", reflect.TypeOf(ptr))
+	}
+
+	fields, err := df.structFields(structType)
+	if err != nil {
+		return series.WrapError("ToStructs", err)
+	}
+	out := reflect.MakeSlice(slice.Type(), df.nrows, df.nrows)
+	for i := 0; i < df.nrows; i++ {
+		item := reflect.New(structType).Elem()
+		for _, f := range fields {
+			col := df.columns[f.col]
+			if err := setField(fieldByIndexAlloc(item, f.index), col.Elem(i)); err != nil {
+				return columnError("ToStructs", col.Name, err)
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			out.Index(i).Set(item.Addr())
+		} else {
+			out.Index(i).Set(item)
+		}
+	}
+	slice.Set(out)
+	return nil
+}
+
+// ToSlice 与 ToStructs 相同，但返回 T 的切片。T 为结构体或结构体指针类型。
+func ToSlice[T any](df DataFrame) ([]T, error) {
+	var out []T
+	if err := df.ToStructs(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// structField 记录结构体字段的索引路径和对应的列索引。
+type structField struct {
+	index []int
+	col   int
+}
+
+// structFields 返回结构体 t 中需要写入的字段及其对应的列。
+func (df DataFrame) structFields(t reflect.Type) ([]structField, error) {
+	cols, err := structColumns(t)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]structField, len(cols))
+	for j, c := range cols {
+		col := df.colIndex(c.name)
+		if col < 0 {
+			return nil, series.ColumnNotFoundError("", c.name)
+		}
+		fields[j] = structField{index: c.index, col: col}
+	}
+	return fields, nil
+}
+
+// 用于检查字段类型的接口和结构体类型
+var (
+	marshalerType       = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// structColumn 描述结构体中对应一列的字段。
+type structColumn struct {
+	name    string       // 列名，嵌套结构体的字段带有前缀
+	tagType string       // 标签中指定的类型名
+	index   []int        // 字段在结构体中的索引路径
+	ftype   reflect.Type // 字段的类型
+}
+
+// structColumns 返回结构体 t 中对应列的字段，嵌入的结构体直接展开，其他嵌套的结构体以 "字段名." 为前缀展开。
+// 实现了 Marshaler、encoding.TextMarshaler 或 driver.Valuer 的结构体（例如 time.Time）不展开。
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	return appendStructColumns(nil, t, "", nil, make(map[reflect.Type]bool))
+}
+
+// appendStructColumns 将结构体 t 的字段追加到 cols，visiting 中的类型不再展开，以免递归定义的类型无限展开。
+func appendStructColumns(cols []structColumn, t reflect.Type, prefix string, index []int, visiting map[reflect.Type]bool) ([]structColumn, error) {
+	visiting[t] = true
+	defer delete(visiting, t)
+	for j := 0; j < t.NumField(); j++ {
+		field := t.Field(j)
+		// 与 encoding/json 相同，未导出的嵌入结构体的导出字段仍然展开
+		if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct && !leafType(field.Type)) {
+			continue
+		}
+		name, typ, skip, err := structTag(field)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			continue
+		}
+		idx := append(append([]int(nil), index...), j)
+		base := field.Type
+		for base.Kind() == reflect.Ptr {
+			base = base.Elem()
+		}
+		if base.Kind() == reflect.Struct && typ == "" && !visiting[base] && !leafType(base) {
+			p := prefix + name + "."
+			if field.Anonymous && name == field.Name {
+				p = prefix
+			}
+			if cols, err = appendStructColumns(cols, base, p, idx, visiting); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		cols = append(cols, structColumn{name: prefix + name, tagType: typ, index: idx, ftype: field.Type})
+	}
+	return cols, nil
+}
+
+// implements 检查类型 t 或其指针类型是否实现了接口 iface。
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// leafType 检查结构体类型 t 是否作为一个值加载，而不是展开为多列。
+func leafType(t reflect.Type) bool {
+	return implements(t, marshalerType) || implements(t, textMarshalerType) || implements(t, valuerType)
+}
+
+// columnType 返回字段对应的列类型。返回空类型表示需要根据值检测类型。
+func (c structColumn) columnType() (series.Type, error) {
+	if c.tagType != "" {
+		return parseType(c.tagType)
+	}
+	t := c.ftype
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(sql.NullInt64{}):
+		return series.Int64, nil
+	case reflect.TypeOf(sql.NullInt32{}):
+		return series.Int32, nil
+	case reflect.TypeOf(sql.NullInt16{}):
+		return series.Int16, nil
+	case reflect.TypeOf(sql.NullByte{}):
+		return series.Uint8, nil
+	case reflect.TypeOf(sql.NullFloat64{}):
+		return series.Float, nil
+	case reflect.TypeOf(sql.NullBool{}):
+		return series.Bool, nil
+	case reflect.TypeOf(sql.NullString{}), reflect.TypeOf(sql.NullTime{}):
+		return series.String, nil
+	}
+	switch {
+	case implements(t, marshalerType), implements(t, textMarshalerType):
+		return series.String, nil
+	case implements(t, valuerType):
+		return "", nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return parseType(t.Kind().String())
+	case reflect.Float32, reflect.Float64:
+		return series.Float, nil
+	case reflect.String:
+		return series.String, nil
+	case reflect.Bool:
+		return series.Bool, nil
+	case reflect.Interface:
+		return "", nil
+	}
+	return "", series.NewError(series.ErrUnknownType, "", "unsupported_type", c.ftype)
+}
+
+// fieldByIndex 返回 v 中索引路径为 index 的字段，路径上有 nil 指针时 ok 为 false。
+func fieldByIndex(v reflect.Value, index []int) (f reflect.Value, ok bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// fieldByIndexAlloc 返回 v 中索引路径为 index 的字段，路径上的 nil 指针会被分配。
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// methodValue 返回可以调用接口 iface 的方法的 fv 或其地址，都没有实现 iface 时返回 nil。
+func methodValue(fv reflect.Value, iface reflect.Type) interface{} {
+	if fv.Type().Implements(iface) {
+		return fv.Interface()
+	}
+	if fv.CanAddr() && reflect.PtrTo(fv.Type()).Implements(iface) {
+		return fv.Addr().Interface()
+	}
+	return nil
+}
+
+// structValue 返回字段 fv 作为元素的值，nil 指针和无效的 sql.Null* 值返回 nil。
+func structValue(fv reflect.Value) (interface{}, error) {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+	if m, ok := methodValue(fv, marshalerType).(Marshaler); ok {
+		return m.MarshalElement()
+	}
+	if m, ok := methodValue(fv, textMarshalerType).(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	if m, ok := methodValue(fv, valuerType).(driver.Valuer); ok {
+		v, err := m.Value()
+		if err != nil {
+			return nil, err
+		}
+		switch x := v.(type) {
+		case time.Time:
+			return x.Format(time.RFC3339Nano), nil
+		case []byte:
+			return string(x), nil
+		}
+		return nativeValue(v), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return nativeInt(fv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nativeUint(fv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), nil
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return fv.Bool(), nil
+	}
+	return nil, series.NewError(series.ErrUnknownType, "", "unsupported_type", fv.Type())
+}
+
+// setField 将元素 e 转换为字段的类型并写入字段 f。
+func setField(f reflect.Value, e series.Element) error {
+	if e.IsNA() {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+	if f.Kind() == reflect.Ptr {
+		p := reflect.New(f.Type().Elem())
+		if err := setField(p.Elem(), e); err != nil {
+			return err
+		}
+		f.Set(p)
+		return nil
+	}
+	if m, ok := methodValue(f, unmarshalerType).(Unmarshaler); ok {
+		if err := m.UnmarshalElement(e.String()); err != nil {
+			return convertError(e, f.Type()).Wrap(err)
+		}
+		return nil
+	}
+	if m, ok := methodValue(f, textUnmarshalerType).(encoding.TextUnmarshaler); ok {
+		if err := m.UnmarshalText([]byte(e.String())); err != nil {
+			return convertError(e, f.Type()).Wrap(err)
+		}
+		return nil
+	}
+	if m, ok := methodValue(f, scannerType).(sql.Scanner); ok {
+		v := e.Val()
+		if err := m.Scan(v); err != nil {
+			// sql.NullTime 等类型不能从字符串扫描时间，LoadStructs 将时间保存为 RFC 3339 格式的字符串
+			if s, ok := v.(string); ok {
+				if t, terr := time.Parse(time.RFC3339Nano, s); terr == nil && m.Scan(t) == nil {
+					return nil
+				}
+			}
+			return convertError(e, f.Type()).Wrap(err)
+		}
+		return nil
+	}
+
+	// 固定位数的整数元素直接使用其值，避免经过 int
+	if v := reflect.ValueOf(e.Val()); series.IsInteger(e.Type()) {
+		switch {
+		case f.CanInt() && v.CanInt() && !f.OverflowInt(v.Int()):
+			f.SetInt(v.Int())
+			return nil
+		case f.CanUint() && v.CanUint() && !f.OverflowUint(v.Uint()):
+			f.SetUint(v.Uint())
+			return nil
+		case f.CanUint() && v.CanInt() && v.Int() >= 0 && !f.OverflowUint(uint64(v.Int())):
+			f.SetUint(uint64(v.Int()))
+			return nil
+		case f.CanInt() && v.CanUint() && v.Uint() <= math.MaxInt64 && !f.OverflowInt(int64(v.Uint())):
+			f.SetInt(int64(v.Uint()))
+			return nil
+		case f.CanInt() || f.CanUint():
+			return convertError(e, f.Type())
+		}
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(e.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := series.ElementInt64(e)
+		if err != nil || f.OverflowInt(i) {
+			return convertError(e, f.Type())
+		}
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := series.ElementInt64(e)
+		if err != nil || i < 0 || f.OverflowUint(uint64(i)) {
+			return convertError(e, f.Type())
+		}
+		f.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		x := e.Float()
+		if math.IsNaN(x) || f.OverflowFloat(x) {
+			return convertError(e, f.Type())
+		}
+		f.SetFloat(x)
+	case reflect.Bool:
+		b, err := e.Bool()
+		if err != nil {
+			return convertError(e, f.Type())
+		}
+		f.SetBool(b)
+	case reflect.Interface:
+		if f.NumMethod() > 0 {
+			return series.NewError(series.ErrUnknownType, "", "unsupported_type", f.Type())
+		}
+		f.Set(reflect.ValueOf(e.Val()))
+	default:
+		return series.NewError(series.ErrUnknownType, "", "unsupported_type", f.Type())
+	}
+	return nil
+}
+
+// convertError 返回无法将元素 e 转换为类型 t 的错误。
+func convertError(e series.Element, t reflect.Type) *series.Error {
+	return series.NewError(series.ErrConversion, "", "convert_value", e.Type(), e.String(), t)
+}
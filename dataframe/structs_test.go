@@ -0,0 +1,297 @@
+package dataframe
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+type tagged struct {
+	Name   string  `dataframe:"name"`
+	Age    int     `dataframe:"age,int"`
+	Score  float64 `dataframe:",float"`
+	Ignore string  `dataframe:"-"`
+	Active bool
+	secret string
+}
+
+func TestStructTags(t *testing.T) {
+	df := LoadStructs([]tagged{{Name: "a", Age: 1, Score: 1.5, Ignore: "x", Active: true, secret: "s"}})
+	checkRecords(t, df, [][]string{{"name", "age", "Score", "Active"}, {"a", "1", "1.500000", "true"}})
+	if got, want := df.Types(), []series.Type{series.String, series.Int, series.Float, series.Bool}; !reflect.DeepEqual(got, want) {
+		t.Errorf("types = %v, want %v", got, want)
+	}
+
+	type decimalTag struct {
+		Price string `dataframe:"price,decimal(10,2)"`
+	}
+	df = LoadStructs([]decimalTag{{"1.5"}})
+	checkRecords(t, df, [][]string{{"price"}, {"1.50"}})
+
+	type badTag struct {
+		X int `dataframe:"x,int,extra"`
+	}
+	if df := LoadStructs([]badTag{{1}}); !errors.Is(df.Err, ErrInvalidArgument) {
+		t.Errorf("bad tag err = %v, want %v", df.Err, ErrInvalidArgument)
+	}
+}
+
+func TestToStructs(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b"}, series.String, "name"),
+		series.New([]interface{}{1, nil}, series.Int, "age"),
+		series.New([]float64{1.5, 2}, series.Float, "Score"),
+		series.New([]bool{true, false}, series.Bool, "Active"),
+		series.New([]string{"x", "y"}, series.String, "extra"),
+	)
+	want := []tagged{{Name: "a", Age: 1, Score: 1.5, Active: true}, {Name: "b", Score: 2}}
+
+	var got []tagged
+	if err := df.ToStructs(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToStructs = %+v, want %+v", got, want)
+	}
+
+	ptrs, err := ToSlice[*tagged](df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range ptrs {
+		if !reflect.DeepEqual(*p, want[i]) {
+			t.Errorf("ToSlice[%d] = %+v, want %+v", i, *p, want[i])
+		}
+	}
+
+	back := LoadStructs(got)
+	checkRecords(t, back, [][]string{{"name", "age", "Score", "Active"}, {"a", "1", "1.500000", "true"}, {"b", "0", "2.000000", "false"}})
+}
+
+func TestToStructsConversions(t *testing.T) {
+	type target struct {
+		I8    int8
+		U16   uint16
+		I64   int64
+		U64   uint64
+		F32   float32
+		B     bool
+		P     *int
+		Any   interface{}
+		T     time.Time
+		Null  sql.NullInt64
+		Label string
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	df := New(
+		series.New([]float64{-3, 4}, series.Float, "I8"),
+		series.New([]string{"7", "65535"}, series.Int, "U16"),
+		series.New([]interface{}{int64(math.MaxInt64), int64(math.MinInt64)}, series.Int64, "I64"),
+		series.New([]interface{}{uint64(math.MaxUint64), uint64(0)}, series.Uint64, "U64"),
+		series.New([]float64{0.5, 1}, series.Float, "F32"),
+		series.New([]int{1, 0}, series.Int, "B"),
+		series.New([]interface{}{5, nil}, series.Int, "P"),
+		series.New([]string{"x", "y"}, series.String, "Any"),
+		series.New([]string{ts.Format(time.RFC3339), "NaN"}, series.String, "T"),
+		series.New([]interface{}{9, nil}, series.Int, "Null"),
+		series.New([]int{1, 2}, series.Int, "Label"),
+	)
+	got, err := ToSlice[target](df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	five := 5
+	want := []target{
+		{I8: -3, U16: 7, I64: math.MaxInt64, U64: math.MaxUint64, F32: 0.5, B: true, P: &five, Any: "x", T: ts,
+			Null: sql.NullInt64{Int64: 9, Valid: true}, Label: "1"},
+		{I8: 4, U16: 65535, I64: math.MinInt64, F32: 1, Any: "y", Label: "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSlice = %+v, want %+v", got, want)
+	}
+}
+
+func TestToStructsErrors(t *testing.T) {
+	type small struct {
+		X int8
+	}
+	type unsigned struct {
+		X uint
+	}
+	type missing struct {
+		Y int
+	}
+	tests := []struct {
+		name string
+		f    func() error
+		kind series.ErrorKind
+	}{
+		{"not pointer", func() error { return New(series.Ints([]int{1})).ToStructs([]small{}) }, ErrInvalidArgument},
+		{"not struct", func() error { var x []int; return New(series.Ints([]int{1})).ToStructs(&x) }, ErrInvalidArgument},
+		{"missing column", func() error { _, err := ToSlice[missing](New(series.New([]int{1}, series.Int, "X"))); return err }, ErrColumnNotFound},
+		{"int8 overflow", func() error { _, err := ToSlice[small](New(series.New([]int{300}, series.Int, "X"))); return err }, ErrConversion},
+		{"fraction", func() error { _, err := ToSlice[small](New(series.New([]float64{1.5}, series.Float, "X"))); return err }, ErrConversion},
+		{"negative uint", func() error { _, err := ToSlice[unsigned](New(series.New([]int{-1}, series.Int, "X"))); return err }, ErrConversion},
+		{"float too large", func() error {
+			_, err := ToSlice[small](New(series.New([]float64{1e30}, series.Float, "X")))
+			return err
+		}, ErrConversion},
+		{"string", func() error { _, err := ToSlice[small](New(series.New([]string{"a"}, series.String, "X"))); return err }, ErrConversion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f()
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+		})
+	}
+
+	_, err := ToSlice[small](New(series.New([]int{300}, series.Int, "X")))
+	if err == nil || !strings.Contains(err.Error(), "X") {
+		t.Errorf("err = %v, want it to name the column", err)
+	}
+}
+
+type point struct {
+	X, Y int
+}
+
+type base struct {
+	ID int `dataframe:"id"`
+}
+
+type celsius float64
+
+func (c celsius) MarshalElement() (string, error) {
+	return strconv.FormatFloat(float64(c), 'f', 1, 64) + "C", nil
+}
+
+type level int
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+type record struct {
+	base
+	Name  *string
+	Pos   point
+	Ptr   *point
+	When  time.Time
+	Count sql.NullInt64
+	Note  sql.NullString
+	Temp  celsius
+	Level level
+}
+
+func TestLoadStructsRich(t *testing.T) {
+	name := "a"
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []record{
+		{base{1}, &name, point{1, 2}, &point{3, 4}, ts, sql.NullInt64{Int64: 7, Valid: true}, sql.NullString{String: "n", Valid: true}, 21.5, 1},
+		{base{2}, nil, point{5, 6}, nil, ts.Add(time.Hour), sql.NullInt64{}, sql.NullString{}, -3, 0},
+	}
+	want := [][]string{
+		{"id", "Name", "Pos.X", "Pos.Y", "Ptr.X", "Ptr.Y", "When", "Count", "Note", "Temp", "Level"},
+		{"1", "a", "1", "2", "3", "4", "2024-01-02T03:04:05Z", "7", "n", "21.5C", "high"},
+		{"2", "NaN", "5", "6", "NaN", "NaN", "2024-01-02T04:04:05Z", "NaN", "NaN", "-3.0C", "low"},
+	}
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{"values", records},
+		{"pointers", []*record{&records[0], &records[1]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := LoadStructs(tt.in)
+			checkRecords(t, df, want)
+			if got := df.Col("Count").Type(); got != series.Int64 {
+				t.Errorf("Count type = %v, want %v", got, series.Int64)
+			}
+		})
+	}
+
+	t.Run("nil element", func(t *testing.T) {
+		df := LoadStructs([]*point{{1, 2}, nil})
+		checkRecords(t, df, [][]string{{"X", "Y"}, {"1", "2"}, {"NaN", "NaN"}})
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		type plain struct {
+			base
+			Name *string
+			Pos  point
+			Ptr  *point
+			When time.Time
+		}
+		in := []plain{{base{1}, &name, point{1, 2}, &point{3, 4}, ts}, {base{2}, nil, point{5, 6}, &point{}, ts}}
+		out, err := ToSlice[plain](LoadStructs(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("round trip = %+v, want %+v", out, in)
+		}
+	})
+}
+
+func TestLoadInt64Extremes(t *testing.T) {
+	type wide struct {
+		I int64
+		U uint64
+	}
+	df := LoadStructs([]wide{{math.MaxInt64, math.MaxUint64}, {math.MinInt64, 0}})
+	checkRecords(t, df, [][]string{
+		{"I", "U"},
+		{"9223372036854775807", "18446744073709551615"},
+		{"-9223372036854775808", "0"},
+	})
+	if got := df.Types(); !reflect.DeepEqual(got, []series.Type{series.Int64, series.Uint64}) {
+		t.Errorf("types = %v, want [%v %v]", got, series.Int64, series.Uint64)
+	}
+
+	for _, x := range []int64{math.MaxInt64, math.MinInt64, 0} {
+		if got, want := fmt.Sprint(nativeValue(x)), strconv.FormatInt(x, 10); got != want {
+			t.Errorf("nativeValue(%d) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestLoadStructsErrors(t *testing.T) {
+	type loop struct {
+		Next *loop
+	}
+	type unsupported struct {
+		C chan int
+	}
+	tests := []struct {
+		name string
+		in   interface{}
+		kind series.ErrorKind
+	}{
+		{"nil", nil, ErrEmpty},
+		{"empty", []point{}, ErrEmpty},
+		{"not slice", point{}, ErrUnknownType},
+		{"not struct", []int{1}, ErrUnknownType},
+		{"recursive", []loop{{}}, ErrUnknownType},
+		{"unsupported field", []unsupported{{}}, ErrUnknownType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if df := LoadStructs(tt.in); !errors.Is(df.Err, tt.kind) {
+				t.Errorf("err = %v, want %v", df.Err, tt.kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"stream/go-sdk/test/gota_study/series"
+)
+
+// Window 表示按分区和排序规则划分的窗口，类似 SQL 中的 OVER (PARTITION BY ... ORDER BY ...)。
+// 窗口函数返回的 Series 与原始DataFrame的行一一对应，可以通过 Mutate 添加到DataFrame中。
+type Window struct {
+	df         DataFrame // 原始DataFrame
+	orderBy    []Order   // 分区内的排序规则
+	partitions [][]int   // 每个分区内按排序规则排列的行索引
+	Err        error     // 错误信息
+}
+
+// Window 方法按照 partitionBy 指定的列划分分区，并在每个分区内按照 orderBy 排序。
+// partitionBy 为空时整个DataFrame为一个分区，orderBy 为空时保持原始行顺序。
+func (df DataFrame) Window(partitionBy []string, orderBy []Order) *Window {
+	if df.Err != nil {
+		return &Window{Err: df.Err}
+	}
+
+	var partitions [][]int
+	if len(partitionBy) == 0 {
+		rows := make([]int, df.nrows)
+		for i := range rows {
+			rows[i] = i
+		}
+		partitions = append(partitions, rows)
+	} else {
+		gps := df.GroupBy(partitionBy...)
+		if gps.Err != nil {
+			return &Window{Err: series.WrapError("Window", gps.Err)}
+		}
+		for _, rows := range gps.indices {
+			partitions = append(partitions, rows)
+		}
+	}
+
+	if len(orderBy) > 0 {
+		for k, rows := range partitions {
+			idx, err := df.Subset(rows).order(orderBy...)
+			if err != nil {
+				return &Window{Err: series.WrapError("Window", err)}
+			}
+			ordered := make([]int, len(idx))
+			for i, j := range idx {
+				ordered[i] = rows[j]
+			}
+			partitions[k] = ordered
+		}
+	}
+
+	return &Window{df: df, orderBy: orderBy, partitions: partitions}
+}
+
+// RowNumber 返回每行在其分区内的序号，从 1 开始。
+func (w Window) RowNumber() series.Series {
+	return w.number("RowNumber", func(rows []int, pos int) int {
+		return pos + 1
+	})
+}
+
+// Rank 返回每行在其分区内的排名，排序值相同的行排名相同，并在之后的排名中留下空缺。
+func (w Window) Rank() series.Series {
+	return w.ranked("Rank", false)
+}
+
+// DenseRank 返回每行在其分区内的排名，排序值相同的行排名相同，之后的排名不留空缺。
+func (w Window) DenseRank() series.Series {
+	return w.ranked("DenseRank", true)
+}
+
+// PercentRank 返回每行在其分区内的相对排名 (rank - 1) / (分区行数 - 1)。分区只有一行时为 0。
+func (w Window) PercentRank() series.Series {
+	if w.Err != nil {
+		return series.Series{Err: w.Err}
+	}
+	rank := w.Rank()
+	ranks, _ := rank.Int()
+	values := make([]float64, w.df.nrows)
+	for _, rows := range w.partitions {
+		for _, i := range rows {
+			if len(rows) > 1 {
+				values[i] = float64(ranks[i]-1) / float64(len(rows)-1)
+			}
+		}
+	}
+	return series.New(values, series.Float, "PercentRank")
+}
+
+// NTile 将每个分区内的行尽量均匀地分为 n 个桶，返回每行所在桶的编号，从 1 开始。
+// 行数不能整除时，靠前的桶多分一行。
+func (w Window) NTile(n int) series.Series {
+	if w.Err != nil {
+		return series.Series{Err: w.Err}
+	}
+	if n <= 0 {
+		return series.Series{Err: series.NewError(series.ErrInvalidArgument, "NTile", "positive_ntile")}
+	}
+	return w.number("NTile", func(rows []int, pos int) int {
+		size, extra := len(rows)/n, len(rows)%n
+		if pos < extra*(size+1) {
+			return pos/(size+1) + 1
+		}
+		return extra + (pos-extra*(size+1))/size + 1
+	})
+}
+
+// Lag 返回每行在其分区内前 n 行的 colname 列的值，不存在时为 NaN。
+func (w Window) Lag(colname string, n int) series.Series {
+	return w.shift("Lag", colname, n, fmt.Sprintf("%s_lag%d", colname, n))
+}
+
+// Lead 返回每行在其分区内后 n 行的 colname 列的值，不存在时为 NaN。
+func (w Window) Lead(colname string, n int) series.Series {
+	return w.shift("Lead", colname, -n, fmt.Sprintf("%s_lead%d", colname, n))
+}
+
+// Running 返回 colname 列在每个分区内从第一行到当前行的滚动聚合值。
+func (w Window) Running(colname string, typ AggregationType) series.Series {
+	return w.apply("Running", colname, func(s series.Series) series.Series {
+		values, err := running(s, typ)
+		if err != nil {
+			return series.Series{Err: err}
+		}
+		return series.New(values, series.Float, s.Name)
+	}, fmt.Sprintf("%s_%s", colname, typ))
+}
+
+// running 返回 s 从第一个元素到每个元素的聚合值，结果与对每个前缀调用 aggregate 相同。
+// SUM、COUNT、MEAN、MAX 和 MIN 逐个元素累计，其他聚合方式对每个前缀重新计算。
+func running(s series.Series, typ AggregationType) ([]float64, error) {
+	values := make([]float64, s.Len())
+	textual := s.Type() == series.String || s.Type() == series.Categorical
+	switch typ {
+	case Aggregation_COUNT:
+		for i := range values {
+			values[i] = float64(i + 1)
+		}
+	case Aggregation_SUM, Aggregation_MEAN:
+		sum := 0.0
+		for i := range values {
+			sum += s.Elem(i).Float()
+			values[i] = sum
+			if typ == Aggregation_MEAN {
+				values[i] = sum / float64(i+1)
+			} else if textual || s.Type() == series.Bool {
+				values[i] = math.NaN()
+			}
+		}
+	case Aggregation_MAX, Aggregation_MIN:
+		var best series.Element
+		for i := range values {
+			e := s.Elem(i)
+			if i == 0 || (typ == Aggregation_MAX && e.Greater(best)) || (typ == Aggregation_MIN && e.Less(best)) {
+				best = e
+			}
+			values[i] = best.Float()
+			if textual {
+				values[i] = math.NaN()
+			}
+		}
+	default:
+		prefix := make([]int, 0, len(values))
+		for i := range values {
+			prefix = append(prefix, i)
+			value, err := aggregate(s.Subset(prefix), typ)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+	}
+	return values, nil
+}
+
+// CumSum 返回 colname 列在每个分区内的累计和。
+func (w Window) CumSum(colname string) series.Series {
+	return w.apply("CumSum", colname, series.Series.CumSum, colname+"_cumsum")
+}
+
+// CumMax 返回 colname 列在每个分区内的累计最大值。
+func (w Window) CumMax(colname string) series.Series {
+	return w.apply("CumMax", colname, series.Series.CumMax, colname+"_cummax")
+}
+
+// CumMin 返回 colname 列在每个分区内的累计最小值。
+func (w Window) CumMin(colname string) series.Series {
+	return w.apply("CumMin", colname, series.Series.CumMin, colname+"_cummin")
+}
+
+// ranked 在每个分区内按排序后的顺序计算排名，dense 为 true 时排名不留空缺。
+// 排序值相同的行在排序后相邻，因此只需比较每对相邻的行。
+func (w Window) ranked(name string, dense bool) series.Series {
+	if w.Err != nil {
+		return series.Series{Err: w.Err}
+	}
+	values := make([]int, w.df.nrows)
+	for _, rows := range w.partitions {
+		rank, distinct := 0, 0
+		for pos, i := range rows {
+			if pos == 0 || !w.peers(rows[pos-1], i) {
+				rank = pos + 1
+				distinct++
+			}
+			values[i] = rank
+			if dense {
+				values[i] = distinct
+			}
+		}
+	}
+	return series.New(values, series.Int, name)
+}
+
+// number 根据每行在分区内的位置计算整数结果。
+func (w Window) number(name string, f func(rows []int, pos int) int) series.Series {
+	if w.Err != nil {
+		return series.Series{Err: w.Err}
+	}
+	values := make([]int, w.df.nrows)
+	for _, rows := range w.partitions {
+		for pos, i := range rows {
+			values[i] = f(rows, pos)
+		}
+	}
+	return series.New(values, series.Int, name)
+}
+
+// shift 在每个分区内按排序后的顺序移动 colname 列，op 为报告错误时使用的操作名称。
+func (w Window) shift(op, colname string, n int, name string) series.Series {
+	return w.apply(op, colname, func(s series.Series) series.Series {
+		return s.Shift(n, nil)
+	}, name)
+}
+
+// apply 在每个分区内按排序后的顺序对 colname 列应用 f，并把结果写回原始行的位置，
+// 返回的 Series 命名为 name。出错时错误记录操作名称 op。
+func (w Window) apply(op, colname string, f func(series.Series) series.Series, name string) series.Series {
+	if w.Err != nil {
+		return series.Series{Err: w.Err}
+	}
+	idx := w.df.colIndex(colname)
+	if idx < 0 {
+		return series.Series{Err: series.ColumnNotFoundError(op, colname)}
+	}
+	col := w.df.columns[idx]
+	ret := col.Empty()
+	ret.Name = name
+	first := true
+	for _, rows := range w.partitions {
+		s := f(col.Subset(rows))
+		if s.Err != nil {
+			return series.Series{Err: columnError(op, colname, s.Err)}
+		}
+		if first {
+			// 使用 s 的空副本保留 Decimal 的规格等类型信息
+			ret = s.Empty()
+			ret.Name = name
+			ret.Append(make([]interface{}, w.df.nrows))
+			first = false
+		}
+		ret = ret.Set(rows, s)
+		if ret.Err != nil {
+			return series.Series{Err: columnError(op, colname, ret.Err)}
+		}
+	}
+	return ret
+}
+
+// peers 判断两行在排序列上的值是否全部相同，NaN 与 NaN 视为相同。使用 Collation 排序的列与排序时一样
+// 按照 Collation 比较，Collation 认为相等的两个值视为相同。
+func (w Window) peers(i, j int) bool {
+	for _, o := range w.orderBy {
+		col := w.df.columns[w.df.colIndex(o.Colname)]
+		a, b := col.Elem(i), col.Elem(j)
+		if a.IsNA() && b.IsNA() {
+			continue
+		}
+		if a.IsNA() || b.IsNA() {
+			return false
+		}
+		if collated(col, o.Collation) {
+			if o.Collation(a.String(), b.String()) != 0 {
+				return false
+			}
+			continue
+		}
+		if !a.Eq(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// collated 检查列是否按照 collation 排序，与 series.Series.Order 的规则相同：
+// 只有 String 列和无序的 Categorical 列使用 Collation。
+func collated(col series.Series, collation series.Collation) bool {
+	if collation == nil {
+		return false
+	}
+	return col.Type() == series.String || (col.Type() == series.Categorical && !col.Ordered())
+}
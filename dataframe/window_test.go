@@ -0,0 +1,99 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"stream/go-sdk/test/gota_study/series"
+)
+
+func TestWindow(t *testing.T) {
+	df := New(
+		series.New([]string{"a", "b", "a", "a", "b", "a"}, series.String, "customer"),
+		series.New([]int{10, 5, 30, 10, 7, 20}, series.Int, "amount"),
+	)
+	w := df.Window([]string{"customer"}, []Order{RevSort("amount")})
+	tests := []struct {
+		name string
+		got  series.Series
+		want []string
+	}{
+		{"row number", w.RowNumber(), []string{"3", "2", "1", "4", "1", "2"}},
+		{"rank", w.Rank(), []string{"3", "2", "1", "3", "1", "2"}},
+		{"dense rank", w.DenseRank(), []string{"3", "2", "1", "3", "1", "2"}},
+		{"percent rank", w.PercentRank(), []string{"0.666667", "1.000000", "0.000000", "0.666667", "0.000000", "0.333333"}},
+		{"ntile", w.NTile(3), []string{"2", "2", "1", "3", "1", "1"}},
+		{"lag", w.Lag("amount", 1), []string{"20", "7", "NaN", "10", "NaN", "30"}},
+		{"lead", w.Lead("amount", 1), []string{"10", "NaN", "20", "NaN", "5", "10"}},
+		{"running sum", w.Running("amount", Aggregation_SUM), []string{"60.000000", "12.000000", "30.000000", "70.000000", "7.000000", "50.000000"}},
+		{"running mean", w.Running("amount", Aggregation_MEAN), []string{"20.000000", "6.000000", "30.000000", "17.500000", "7.000000", "25.000000"}},
+		{"running min", w.Running("amount", Aggregation_MIN), []string{"10.000000", "5.000000", "30.000000", "10.000000", "7.000000", "20.000000"}},
+		{"running count", w.Running("amount", Aggregation_COUNT), []string{"3.000000", "2.000000", "1.000000", "4.000000", "1.000000", "2.000000"}},
+		{"running median", w.Running("amount", Aggregation_MEDIAN), []string{"20.000000", "6.000000", "30.000000", "15.000000", "7.000000", "25.000000"}},
+		{"cumsum", w.CumSum("amount"), []string{"60", "12", "30", "70", "7", "50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Err != nil {
+				t.Fatalf("unexpected error: %v", tt.got.Err)
+			}
+			if got := tt.got.Records(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowTies(t *testing.T) {
+	df := New(series.New([]interface{}{3, 1, 3, nil, 1, 2}, series.Int, "score"))
+	w := df.Window(nil, []Order{Sort("score")})
+	if got, want := w.Rank().Records(), []string{"4", "1", "4", "6", "1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rank = %v, want %v", got, want)
+	}
+	if got, want := w.DenseRank().Records(), []string{"3", "1", "3", "4", "1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dense rank = %v, want %v", got, want)
+	}
+}
+
+func TestWindowCollatedTies(t *testing.T) {
+	df := New(series.New([]string{"a", "A", "b"}, series.String, "k"))
+	tests := []struct {
+		name  string
+		order Order
+		rank  []string
+		dense []string
+	}{
+		{"case insensitive", Sort("k", series.CollateCaseInsensitive()), []string{"1", "1", "3"}, []string{"1", "1", "2"}},
+		{"case insensitive reverse", RevSort("k", series.CollateCaseInsensitive()), []string{"2", "2", "1"}, []string{"2", "2", "1"}},
+		{"byte order", Sort("k"), []string{"2", "1", "3"}, []string{"2", "1", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := df.Window(nil, []Order{tt.order})
+			if got := w.Rank().Records(); !reflect.DeepEqual(got, tt.rank) {
+				t.Errorf("rank = %v, want %v", got, tt.rank)
+			}
+			if got := w.DenseRank().Records(); !reflect.DeepEqual(got, tt.dense) {
+				t.Errorf("dense rank = %v, want %v", got, tt.dense)
+			}
+		})
+	}
+}
+
+func TestWindowErrors(t *testing.T) {
+	df := New(series.New([]int{1, 2}, series.Int, "x"))
+	if got := df.Window([]string{"missing"}, nil).RowNumber(); !errors.Is(got.Err, ErrColumnNotFound) {
+		t.Errorf("partition: err = %v, want ErrColumnNotFound", got.Err)
+	}
+	w := df.Window(nil, nil)
+	if got := w.Lag("missing", 1); !errors.Is(got.Err, ErrColumnNotFound) {
+		t.Errorf("lag: err = %v, want ErrColumnNotFound", got.Err)
+	}
+	if got := w.NTile(0); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("ntile: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	if got := w.Running("x", AggregationType(0)); got.Err == nil {
+		t.Error("running with unknown aggregation: expected error")
+	}
+}
@@ -0,0 +1,126 @@
+package series
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Collation 定义字符串的排序规则。返回值小于 0 表示 a 排在 b 之前，等于 0 表示两者相同，
+// 大于 0 表示 a 排在 b 之后。Collation 只影响 String 和 Categorical 类型 Series 的排序与比较，
+// 有序的 Categorical Series 仍按照类别顺序排序。
+type Collation func(a, b string) int
+
+// CollateLocale 返回按照 BCP 47 语言标签 tag 的本地化规则排序的 Collation，例如 "zh" 表示
+// 按拼音排序，"zh-u-co-stroke" 表示按笔画排序。无法识别的标签使用通用的 Unicode 排序算法。
+func CollateLocale(tag string) Collation {
+	t, err := language.Parse(tag)
+	if err != nil {
+		t = language.Und
+	}
+	c := collate.New(t)
+	// collate.Collator 不能并发使用
+	var mu sync.Mutex
+	return func(a, b string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.CompareString(a, b)
+	}
+}
+
+// CollatePinyin 返回按照汉语拼音排序的 Collation。
+func CollatePinyin() Collation {
+	return CollateLocale("zh")
+}
+
+// CollateStroke 返回按照汉字笔画排序的 Collation。
+func CollateStroke() Collation {
+	return CollateLocale("zh-u-co-stroke")
+}
+
+// CollateUnicode 返回按照通用 Unicode 排序算法 (UCA) 排序的 Collation。
+func CollateUnicode() Collation {
+	return CollateLocale("und")
+}
+
+// CollateCaseInsensitive 返回忽略大小写的 Collation。只有大小写不同的字符串视为相等，
+// 排序时保持原来的相对顺序，窗口函数的 Rank 和 DenseRank 也将它们视为并列。
+func CollateCaseInsensitive() Collation {
+	return func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+// CollateNatural 返回自然排序的 Collation，字符串中的连续数字按数值比较，例如 "file2" 排在 "file10" 之前。
+func CollateNatural() Collation {
+	return naturalCompare
+}
+
+// naturalCompare 按自然顺序比较两个字符串。
+func naturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, na := utf8.DecodeRuneInString(a[i:])
+		rb, nb := utf8.DecodeRuneInString(b[j:])
+		if isDigit(ra) && isDigit(rb) {
+			ea, eb := i, j
+			for ea < len(a) && isDigit(rune(a[ea])) {
+				ea++
+			}
+			for eb < len(b) && isDigit(rune(b[eb])) {
+				eb++
+			}
+			da := strings.TrimLeft(a[i:ea], "0")
+			db := strings.TrimLeft(b[j:eb], "0")
+			if len(da) != len(db) {
+				return compareInts(len(da), len(db))
+			}
+			if c := strings.Compare(da, db); c != 0 {
+				return c
+			}
+			i, j = ea, eb
+			continue
+		}
+		if ra != rb {
+			return compareInts(int(ra), int(rb))
+		}
+		i, j = i+na, j+nb
+	}
+	if c := compareInts(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// isDigit 检查字符是否为 ASCII 数字。
+func isDigit(r rune) bool {
+	return r < utf8.RuneSelf && unicode.IsDigit(r)
+}
+
+// collated 检查 Series 是否应该使用给定的 Collation 排序，返回使用的 Collation。
+func (s Series) collated(collation []Collation) (Collation, bool) {
+	if len(collation) == 0 || collation[0] == nil {
+		return nil, false
+	}
+	if s.t != String && s.t != Categorical {
+		return nil, false
+	}
+	if s.Ordered() {
+		return nil, false
+	}
+	return collation[0], true
+}
+
+// collatedElements 是按照 Collation 排序的 indexedElement 切片。
+type collatedElements struct {
+	indexedElements
+	collation Collation
+}
+
+// Less 方法按照 Collation 比较两个元素的大小。
+func (e collatedElements) Less(i, j int) bool {
+	return e.collation(e.indexedElements[i].element.String(), e.indexedElements[j].element.String()) < 0
+}
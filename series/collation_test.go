@@ -0,0 +1,80 @@
+package series
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollation(t *testing.T) {
+	names := New([]string{"张飞", "刘备", "曹操", "关羽"}, String, "name")
+	files := New([]string{"file10", "File2", "file2", "file1"}, String, "file")
+	tests := []struct {
+		name    string
+		s       Series
+		c       Collation
+		reverse bool
+		want    []string
+	}{
+		{"byte order", names, nil, false, []string{"关羽", "刘备", "张飞", "曹操"}},
+		{"pinyin", names, CollatePinyin(), false, []string{"曹操", "关羽", "刘备", "张飞"}},
+		{"pinyin reverse", names, CollatePinyin(), true, []string{"张飞", "刘备", "关羽", "曹操"}},
+		{"natural", files, CollateNatural(), false, []string{"File2", "file1", "file2", "file10"}},
+		{"case insensitive", files, CollateCaseInsensitive(), false, []string{"file1", "file10", "File2", "file2"}},
+		{"unicode", New([]string{"b", "B", "a"}, String, "x"), CollateUnicode(), false, []string{"a", "b", "B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order []int
+			if tt.c == nil {
+				order = tt.s.Order(tt.reverse)
+			} else {
+				order = tt.s.Order(tt.reverse, tt.c)
+			}
+			checkSeries(t, tt.s.Subset(order), String, tt.want)
+		})
+	}
+}
+
+func TestCollationStroke(t *testing.T) {
+	s := New([]string{"曹操", "王", "刘备"}, String, "name")
+	// 王 4 画，刘 6 画，曹 11 画
+	checkSeries(t, s.Subset(s.Order(false, CollateStroke())), String, []string{"王", "刘备", "曹操"})
+}
+
+func TestCollationMinMax(t *testing.T) {
+	s := New([]string{"张飞", "刘备", "曹操", "关羽"}, String, "name")
+	if got := s.MinStr(CollatePinyin()); got != "曹操" {
+		t.Errorf("min = %q, want 曹操", got)
+	}
+	if got := s.MaxStr(CollatePinyin()); got != "张飞" {
+		t.Errorf("max = %q, want 张飞", got)
+	}
+	if got := s.MaxStr(); got != "曹操" {
+		t.Errorf("byte order max = %q, want 曹操", got)
+	}
+}
+
+func TestCollationIgnoredForOrderedCategorical(t *testing.T) {
+	s := NewCategorical([]string{"b", "a", "c"}, []string{"c", "b", "a"}, true, "x")
+	if got, want := s.Order(false, CollateNatural()), []int{2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file010", "file10", -1},
+		{"a", "a", 0},
+		{"a1b", "a1", 1},
+		{"第2章", "第10章", -1},
+	}
+	for _, tt := range tests {
+		if got := naturalCompare(tt.a, tt.b); got != tt.want {
+			t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
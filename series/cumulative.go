@@ -0,0 +1,257 @@
+package series
+
+import (
+	"math"
+	"math/big"
+)
+
+// CumSum 返回 Series 的累计和。NaN 元素在结果中保持为 NaN，并在累计时被跳过。
+// 整数类型的结果超出类型的范围时返回错误，Decimal 类型精确累计并保留原有的规格。
+func (s Series) CumSum() Series {
+	return s.cumulate("cumsum",
+		func(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) },
+		func(a, b float64) float64 { return a + b },
+		func(a, b *decimalElement) (*big.Int, int) {
+			p, q, scale := alignDecimals(a.v, a.scale, b.v, b.scale)
+			return new(big.Int).Add(p, q), scale
+		},
+	)
+}
+
+// CumProd 返回 Series 的累计积。NaN 元素在结果中保持为 NaN，并在累计时被跳过。
+// 整数类型的结果超出类型的范围时返回错误；Decimal 类型保留原有的规格，固定小数位数时每一步的积按照
+// RoundHalfEven 舍入到该小数位数，不固定小数位数时积的小数位数超过 1000 返回错误。
+func (s Series) CumProd() Series {
+	return s.cumulate("cumprod",
+		func(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) },
+		func(a, b float64) float64 { return a * b },
+		func(a, b *decimalElement) (*big.Int, int) {
+			return new(big.Int).Mul(a.v, b.v), a.scale + b.scale
+		},
+	)
+}
+
+// CumMax 返回 Series 的累计最大值。NaN 元素在结果中保持为 NaN，并在累计时被跳过。
+func (s Series) CumMax() Series {
+	return s.cumulate("cummax",
+		func(a, b *big.Int) *big.Int {
+			if b.Cmp(a) > 0 {
+				return b
+			}
+			return a
+		},
+		math.Max,
+		func(a, b *decimalElement) (*big.Int, int) {
+			if p, q, _ := alignDecimals(a.v, a.scale, b.v, b.scale); q.Cmp(p) > 0 {
+				return b.v, b.scale
+			}
+			return a.v, a.scale
+		},
+	)
+}
+
+// CumMin 返回 Series 的累计最小值。NaN 元素在结果中保持为 NaN，并在累计时被跳过。
+func (s Series) CumMin() Series {
+	return s.cumulate("cummin",
+		func(a, b *big.Int) *big.Int {
+			if b.Cmp(a) < 0 {
+				return b
+			}
+			return a
+		},
+		math.Min,
+		func(a, b *decimalElement) (*big.Int, int) {
+			if p, q, _ := alignDecimals(a.v, a.scale, b.v, b.scale); q.Cmp(p) < 0 {
+				return b.v, b.scale
+			}
+			return a.v, a.scale
+		},
+	)
+}
+
+// cumulate 使用给定的累计函数依次处理 Series 的元素。Int 和固定位数的整数类型按整数累计，
+// 结果超出类型的范围时返回错误；Float 类型按浮点数累计；Decimal 类型精确累计，结果使用与 s 相同的规格，
+// 每一步的结果先按照规格舍入再参与下一步累计，超出规格的精度时返回错误。其他类型返回错误。
+func (s Series) cumulate(op string, fi func(a, b *big.Int) *big.Int, ff func(a, b float64) float64,
+	fd func(a, b *decimalElement) (*big.Int, int)) Series {
+	if s.Err != nil {
+		return s
+	}
+	switch kind, ok := intKindOf(s.t); {
+	case ok:
+		values := make([]interface{}, s.Len())
+		var acc *big.Int
+		for i := 0; i < s.Len(); i++ {
+			e := s.elements.Elem(i)
+			if e.IsNA() {
+				continue
+			}
+			if acc == nil {
+				acc = integerValue(e)
+			} else {
+				acc = fi(acc, integerValue(e))
+			}
+			if !kind.fitsBig(acc) {
+				return s.failed(op, NewError(ErrConversion, "", "int_overflow", acc, kind.t))
+			}
+			values[i] = kind.value(acc)
+		}
+		return New(values, s.t, s.Name)
+	case s.t == Float:
+		values := make([]interface{}, s.Len())
+		var acc float64
+		started := false
+		for i := 0; i < s.Len(); i++ {
+			e := s.elements.Elem(i)
+			if e.IsNA() {
+				continue
+			}
+			if started {
+				acc = ff(acc, e.Float())
+			} else {
+				acc = e.Float()
+				started = true
+			}
+			values[i] = acc
+		}
+		return New(values, s.t, s.Name)
+	case s.t == Decimal:
+		elements := s.elements.(decimalElements)
+		ret := newDecimalElements(elements.Len(), elements.spec)
+		var acc *decimalElement
+		for i := range elements.elements {
+			e := &elements.elements[i]
+			if e.IsNA() {
+				ret.elements[i].nan = true
+				continue
+			}
+			v, scale := e.v, e.scale
+			if acc != nil {
+				v, scale = fd(acc, e)
+			}
+			if err := setDecimal(ret, i, v, scale); err != nil {
+				return s.failed(op, err)
+			}
+			acc = &ret.elements[i]
+		}
+		return Series{Name: s.Name, elements: ret, t: Decimal}
+	}
+	return s.failed(op, NewError(ErrUnknownType, "", "unsupported_type", s.t))
+}
+
+// setDecimal 将 v × 10^-scale 设置为 ret 的第 i 个元素并按照 ret 的规格舍入。结果超出规格的精度，
+// 或者规格不固定小数位数而结果的小数位数超过 maxDecimalScale 时返回错误。
+func setDecimal(ret decimalElements, i int, v *big.Int, scale int) error {
+	if ret.spec.scale < 0 && scale > maxDecimalScale {
+		return NewError(ErrConversion, "", "scale_too_large", scale, maxDecimalScale)
+	}
+	e := &ret.elements[i]
+	e.v, e.scale = v, scale
+	e.fit()
+	if e.nan {
+		value := decimalElement{v: v, scale: scale, spec: freeDecimal}
+		return NewError(ErrConversion, "", "int_overflow", value.String(), DecimalOf(ret.spec.precision, ret.spec.scale))
+	}
+	return nil
+}
+
+// failed 返回带有 op 的错误信息的空 Series。
+func (s Series) failed(op string, err error) Series {
+	empty := s.Empty()
+	empty.Err = WrapError(op, err)
+	return empty
+}
+
+// Diff 返回每个元素与其前 periods 个位置的元素之差。periods 为负数时与其后的元素相减。
+// 超出范围或包含 NaN 的位置结果为 NaN。整数类型的 Series 返回相同的类型，差超出类型的范围时返回错误；
+// Float 类型返回 Float；Decimal 类型精确相减并保留原有的规格，差超出规格的精度时返回错误。
+func (s Series) Diff(periods int) Series {
+	if s.Err != nil {
+		return s
+	}
+	kind, isInt := intKindOf(s.t)
+	if !isInt && s.t != Float && s.t != Decimal {
+		return s.failed("diff", NewError(ErrUnknownType, "", "unsupported_type", s.t))
+	}
+	values := make([]interface{}, s.Len())
+	var decimals decimalElements
+	if s.t == Decimal {
+		decimals = newDecimalElements(s.Len(), s.elements.(decimalElements).spec)
+	}
+	for i := 0; i < s.Len(); i++ {
+		j := i - periods
+		a, b := s.elements.Elem(i), Element(nil)
+		if j >= 0 && j < s.Len() {
+			b = s.elements.Elem(j)
+		}
+		if b == nil || a.IsNA() || b.IsNA() {
+			if decimals.elements != nil {
+				decimals.elements[i].nan = true
+			}
+			continue
+		}
+		switch {
+		case isInt:
+			d := new(big.Int).Sub(integerValue(a), integerValue(b))
+			if !kind.fitsBig(d) {
+				return s.failed("diff", NewError(ErrConversion, "", "int_overflow", d, kind.t))
+			}
+			values[i] = kind.value(d)
+		case s.t == Decimal:
+			x, y := a.(*decimalElement), b.(*decimalElement)
+			p, q, scale := alignDecimals(x.v, x.scale, y.v, y.scale)
+			if err := setDecimal(decimals, i, new(big.Int).Sub(p, q), scale); err != nil {
+				return s.failed("diff", err)
+			}
+		default:
+			values[i] = a.Float() - b.Float()
+		}
+	}
+	if s.t == Decimal {
+		return Series{Name: s.Name, elements: decimals, t: Decimal}
+	}
+	return New(values, s.t, s.Name)
+}
+
+// PctChange 返回每个元素相对于其前 periods 个位置的元素的变化率，结果为 Float 类型的 Series。
+// 支持整数类型、Float 和 Decimal，超出范围或包含 NaN 的位置结果为 NaN。
+func (s Series) PctChange(periods int) Series {
+	if s.Err != nil {
+		return s
+	}
+	if !IsNumeric(s.t) {
+		return s.failed("pct change", NewError(ErrUnknownType, "", "unsupported_type", s.t))
+	}
+	values := make([]float64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		values[i] = math.NaN()
+		j := i - periods
+		if j < 0 || j >= s.Len() {
+			continue
+		}
+		a, b := s.elements.Elem(i), s.elements.Elem(j)
+		if a.IsNA() || b.IsNA() {
+			continue
+		}
+		values[i] = a.Float()/b.Float() - 1
+	}
+	return New(values, Float, s.Name)
+}
+
+// Shift 将 Series 的元素移动 periods 个位置，periods 为负数时向前移动。
+// 空出的位置使用 fill 填充，fill 为 nil 时填充 NaN。
+func (s Series) Shift(periods int, fill interface{}) Series {
+	if s.Err != nil {
+		return s
+	}
+	values := make([]interface{}, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		j := i - periods
+		if j < 0 || j >= s.Len() {
+			values[i] = fill
+			continue
+		}
+		values[i] = s.elements.Elem(j).Val()
+	}
+	return New(values, s.t, s.Name)
+}
@@ -0,0 +1,117 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCumulative(t *testing.T) {
+	ints := New([]interface{}{1, nil, 3, 4}, Int, "a")
+	floats := New([]interface{}{1.5, nil, -2.0, 4.0}, Float, "b")
+	tests := []struct {
+		name string
+		got  Series
+		typ  Type
+		want []string
+	}{
+		{"cumsum int", ints.CumSum(), Int, []string{"1", "NaN", "4", "8"}},
+		{"cumprod int", ints.CumProd(), Int, []string{"1", "NaN", "3", "12"}},
+		{"cummax int", ints.CumMax(), Int, []string{"1", "NaN", "3", "4"}},
+		{"cummin int", ints.CumMin(), Int, []string{"1", "NaN", "1", "1"}},
+		{"cumsum float", floats.CumSum(), Float, []string{"1.500000", "NaN", "-0.500000", "3.500000"}},
+		{"cummin float", floats.CumMin(), Float, []string{"1.500000", "NaN", "-2.000000", "-2.000000"}},
+		{"diff 1", ints.Diff(1), Int, []string{"NaN", "NaN", "NaN", "1"}},
+		{"diff -1", New([]int{1, 3, 6}, Int, "a").Diff(-1), Int, []string{"-2", "-3", "NaN"}},
+		{"diff 2", New([]float64{1, 2, 4, 8}, Float, "a").Diff(2), Float, []string{"NaN", "NaN", "3.000000", "6.000000"}},
+		{"pct change", New([]int{2, 3, 6}, Int, "a").PctChange(1), Float, []string{"NaN", "0.500000", "1.000000"}},
+		{"shift fill", ints.Shift(1, 0), Int, []string{"0", "1", "NaN", "3"}},
+		{"shift back", ints.Shift(-2, nil), Int, []string{"3", "4", "NaN", "NaN"}},
+		{"shift string", New([]string{"x", "y"}, String, "s").Shift(1, "-"), String, []string{"-", "x"}},
+		{"empty", New([]int{}, Int, "a").CumSum(), Int, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.got, tt.typ, tt.want)
+		})
+	}
+}
+
+func TestCumulativeUnsupported(t *testing.T) {
+	s := New([]string{"a", "b"}, String, "s")
+	for name, got := range map[string]Series{
+		"cumsum":     s.CumSum(),
+		"diff":       s.Diff(1),
+		"pct change": s.PctChange(1),
+	} {
+		if !errors.Is(got.Err, ErrUnknownType) {
+			t.Errorf("%s: err = %v, want ErrUnknownType", name, got.Err)
+		}
+	}
+}
+
+func TestCumulativeSizedAndDecimal(t *testing.T) {
+	i8 := New([]interface{}{100, nil, 20, 7}, Int8, "a")
+	u64 := New([]interface{}{uint64(math.MaxUint64 - 1), uint64(1)}, Uint64, "u")
+	dec := New([]string{"0.1", "NaN", "0.25", "-1"}, DecimalOf(10, 2), "d")
+	tests := []struct {
+		name string
+		got  Series
+		typ  Type
+		want []string
+	}{
+		{"cumsum int8", i8.CumSum(), Int8, []string{"100", "NaN", "120", "127"}},
+		{"cummax int8", i8.CumMax(), Int8, []string{"100", "NaN", "100", "100"}},
+		{"cummin int8", i8.CumMin(), Int8, []string{"100", "NaN", "20", "7"}},
+		{"cumsum uint64", u64.CumSum(), Uint64, []string{"18446744073709551614", "18446744073709551615"}},
+		{"cumprod int64", New([]int64{math.MaxInt64 / 2, 2}, Int64, "").CumProd(), Int64, []string{"4611686018427387903", "9223372036854775806"}},
+		{"diff int16", New([]int{1, 300, -5}, Int16, "").Diff(1), Int16, []string{"NaN", "299", "-305"}},
+		{"diff uint64", New([]interface{}{uint64(math.MaxUint64), uint64(1)}, Uint64, "").Diff(-1), Uint64, []string{"18446744073709551614", "NaN"}},
+		{"pct change int32", New([]int{2, 3}, Int32, "").PctChange(1), Float, []string{"NaN", "0.500000"}},
+		{"cumsum decimal", dec.CumSum(), Decimal, []string{"0.10", "NaN", "0.35", "-0.65"}},
+		{"cumprod decimal", dec.CumProd(), Decimal, []string{"0.10", "NaN", "0.02", "-0.02"}},
+		{"cumprod decimal rounding", New([]string{"1.5", "1.5", "1.5"}, DecimalOf(10, 2), "").CumProd(), Decimal, []string{"1.50", "2.25", "3.38"}},
+		{"cumprod free decimal", New([]string{"1.5", "1.5", "1.5"}, Decimal, "").CumProd(), Decimal, []string{"1.5", "2.25", "3.375"}},
+		{"cummax decimal", dec.CumMax(), Decimal, []string{"0.10", "NaN", "0.25", "0.25"}},
+		{"cummin decimal", dec.CumMin(), Decimal, []string{"0.10", "NaN", "0.10", "-1.00"}},
+		{"diff decimal", dec.Diff(1), Decimal, []string{"NaN", "NaN", "NaN", "-1.25"}},
+		{"pct change decimal", New([]string{"2", "2.5"}, Decimal, "").PctChange(1), Float, []string{"NaN", "0.250000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.got, tt.typ, tt.want)
+		})
+	}
+
+	spec := dec.elements.(decimalElements).spec
+	for name, got := range map[string]Series{"cumsum": dec.CumSum(), "cumprod": dec.CumProd(), "cummax": dec.CumMax(), "cummin": dec.CumMin(), "diff": dec.Diff(1)} {
+		if got.elements.(decimalElements).spec != spec {
+			t.Errorf("%s spec = %v, want %v", name, got.elements.(decimalElements).spec, spec)
+		}
+	}
+}
+
+func TestCumulativeOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Series
+	}{
+		{"cumsum int8", New([]int{100, 28}, Int8, "").CumSum()},
+		{"cumprod int16", New([]int{300, 300}, Int16, "").CumProd()},
+		{"cumsum uint64", New([]interface{}{uint64(math.MaxUint64), uint64(1)}, Uint64, "").CumSum()},
+		{"cumsum int", New([]int{math.MaxInt64, 1}, Int, "").CumSum()},
+		{"diff uint8", New([]int{1, 2}, Uint8, "").Diff(-1)},
+		{"diff int64", New([]int64{math.MinInt64, 1}, Int64, "").Diff(-1)},
+		{"cumsum decimal precision", New([]string{"9", "1"}, DecimalOf(3, 2), "").CumSum()},
+		{"diff decimal precision", New([]string{"9", "-9"}, DecimalOf(3, 2), "").Diff(1)},
+		{"cumprod decimal scale", New(strings.Split(strings.Repeat("0.1,", 1000)+"0.1", ","), Decimal, "").CumProd()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.got.Err, ErrConversion) {
+				t.Errorf("err = %v, want %v", tt.got.Err, ErrConversion)
+			}
+		})
+	}
+}
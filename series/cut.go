@@ -0,0 +1,177 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Cut 将数值类型（整数类型、Float 或 Decimal）的 Series 按照 edges 给定的边界划分到区间中，元素按浮点数
+// 与边界比较，返回由区间标签组成的 String Series 以及使用的区间边界的副本。edges 必须严格递增、不包含 NaN 且至少包含两个值；labels 为 nil 时使用区间表示作为标签，
+// 否则其长度必须为 len(edges)-1。right 为 true 时区间为左开右闭 (a, b]，否则为左闭右开 [a, b)；
+// includeLowest 为 true 时第一个区间(right 为 false 时为最后一个区间)同时包含其外侧边界。
+// 超出所有区间的元素以及 NaN 元素的结果为 NaN。
+func Cut(s Series, edges []float64, labels []string, right, includeLowest bool) (Series, []float64) {
+	if s.Err != nil {
+		return s, nil
+	}
+	ret := New([]string{}, String, s.Name)
+	if !IsNumeric(s.t) {
+		ret.Err = NewError(ErrUnknownType, "cut", "unsupported_type", s.t)
+		return ret, nil
+	}
+	if len(edges) < 2 {
+		ret.Err = NewError(ErrInvalidArgument, "cut", "min_edges")
+		return ret, nil
+	}
+	for _, edge := range edges {
+		if math.IsNaN(edge) {
+			ret.Err = NewError(ErrInvalidArgument, "cut", "edges_nan")
+			return ret, nil
+		}
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i] <= edges[i-1] {
+			ret.Err = NewError(ErrInvalidArgument, "cut", "edges_increasing")
+			return ret, nil
+		}
+	}
+	edges = append([]float64(nil), edges...)
+	if labels == nil {
+		labels = intervalLabels(edges, right, includeLowest)
+	}
+	if len(labels) != len(edges)-1 {
+		ret.Err = NewError(ErrDimensionMismatch, "cut", "labels_count")
+		return ret, nil
+	}
+
+	n := len(edges) - 1
+	values := make([]interface{}, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		x := e.Float()
+		var bin int
+		if right {
+			bin = sort.SearchFloat64s(edges, x) - 1
+			if includeLowest && x == edges[0] {
+				bin = 0
+			}
+		} else {
+			bin = sort.Search(len(edges), func(k int) bool { return edges[k] > x }) - 1
+			if includeLowest && x == edges[n] {
+				bin = n - 1
+			}
+		}
+		if bin < 0 || bin >= n {
+			continue
+		}
+		values[i] = labels[bin]
+	}
+	ret = New(values, String, s.Name)
+	return ret, edges
+}
+
+// QCut 将数值类型的 Series 按照分位数划分为 q 个元素数量大致相同的区间，返回由区间标签组成的
+// String Series 以及使用的区间边界。labels 为 nil 时使用区间表示作为标签，否则其长度必须为 q。
+// 区间边界通过 Quantile 计算，计算时忽略 NaN 元素。
+func QCut(s Series, q int, labels []string) (Series, []float64) {
+	if s.Err != nil {
+		return s, nil
+	}
+	if !IsNumeric(s.t) {
+		ret := New([]string{}, String, s.Name)
+		ret.Err = NewError(ErrUnknownType, "qcut", "unsupported_type", s.t)
+		return ret, nil
+	}
+	if q < 1 {
+		ret := New([]string{}, String, s.Name)
+		ret.Err = NewError(ErrInvalidArgument, "qcut", "positive_quantiles")
+		return ret, nil
+	}
+	var valid []int
+	for i := 0; i < s.Len(); i++ {
+		if !s.elements.Elem(i).IsNA() {
+			valid = append(valid, i)
+		}
+	}
+	if len(valid) == 0 {
+		ret := New([]string{}, String, s.Name)
+		ret.Err = NewError(ErrEmpty, "qcut", "no_valid_elements")
+		return ret, nil
+	}
+	notNA := s.Subset(valid)
+	edges := make([]float64, q+1)
+	edges[0] = notNA.Min()
+	for i := 1; i < q; i++ {
+		edges[i] = notNA.Quantile(float64(i) / float64(q))
+	}
+	edges[q] = notNA.Max()
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			ret := New([]string{}, String, s.Name)
+			ret.Err = NewError(ErrInvalidArgument, "qcut", "duplicate_edges", edges)
+			return ret, nil
+		}
+	}
+	ret, edges := Cut(s, edges, labels, true, true)
+	if ret.Err != nil {
+		ret.Err = WrapError("qcut", ret.Err)
+	}
+	return ret, edges
+}
+
+// CutCategorical 与 Cut 相同，但返回有序的 Categorical Series，类别为按区间顺序排列的标签，
+// 因此结果按区间的顺序比较大小和排序。
+func CutCategorical(s Series, edges []float64, labels []string, right, includeLowest bool) (Series, []float64) {
+	ret, used := Cut(s, edges, labels, right, includeLowest)
+	return binCategorical(ret, used, labels, right, includeLowest), used
+}
+
+// QCutCategorical 与 QCut 相同，但返回有序的 Categorical Series，类别为按区间顺序排列的标签。
+func QCutCategorical(s Series, q int, labels []string) (Series, []float64) {
+	ret, used := QCut(s, q, labels)
+	return binCategorical(ret, used, labels, true, true), used
+}
+
+// binCategorical 将 Cut 返回的标签 Series 转换为以区间标签为有序类别的 Categorical Series。
+func binCategorical(bins Series, edges []float64, labels []string, right, includeLowest bool) Series {
+	if bins.Err != nil {
+		ret := NewCategorical([]string{}, labels, true, bins.Name)
+		ret.Err = bins.Err
+		return ret
+	}
+	if labels == nil {
+		labels = intervalLabels(edges, right, includeLowest)
+	}
+	return NewCategorical(bins, labels, true, bins.Name)
+}
+
+// intervalLabels 根据区间边界生成区间的字符串表示，例如 "(0, 18]"。
+func intervalLabels(edges []float64, right, includeLowest bool) []string {
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	n := len(edges) - 1
+	labels := make([]string, n)
+	for i := 0; i < n; i++ {
+		a, b := format(edges[i]), format(edges[i+1])
+		if right {
+			open := "("
+			if includeLowest && i == 0 {
+				open = "["
+			}
+			labels[i] = fmt.Sprintf("%s%s, %s]", open, a, b)
+		} else {
+			closing := ")"
+			if includeLowest && i == n-1 {
+				closing = "]"
+			}
+			labels[i] = fmt.Sprintf("[%s, %s%s", a, b, closing)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,149 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	s := New([]interface{}{0, 18, 19, 65, 70, nil}, Int, "age")
+	edges := []float64{0, 18, 65}
+	tests := []struct {
+		name          string
+		labels        []string
+		right, lowest bool
+		want          []string
+	}{
+		{"right", nil, true, false, []string{"NaN", "(0, 18]", "(18, 65]", "(18, 65]", "NaN", "NaN"}},
+		{"right include lowest", nil, true, true, []string{"[0, 18]", "[0, 18]", "(18, 65]", "(18, 65]", "NaN", "NaN"}},
+		{"left", nil, false, false, []string{"[0, 18)", "[18, 65)", "[18, 65)", "NaN", "NaN", "NaN"}},
+		{"left include highest", nil, false, true, []string{"[0, 18)", "[18, 65]", "[18, 65]", "[18, 65]", "NaN", "NaN"}},
+		{"labels", []string{"minor", "adult"}, true, true, []string{"minor", "minor", "adult", "adult", "NaN", "NaN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, used := Cut(s, edges, tt.labels, tt.right, tt.lowest)
+			checkSeries(t, got, String, tt.want)
+			if !reflect.DeepEqual(used, edges) {
+				t.Errorf("edges = %v, want %v", used, edges)
+			}
+		})
+	}
+}
+
+func TestCutEdgesCopied(t *testing.T) {
+	edges := []float64{0, 1, 2}
+	_, used := Cut(New([]float64{0.5}, Float, "x"), edges, nil, true, false)
+	used[0] = -1
+	if edges[0] != 0 {
+		t.Errorf("modifying the returned edges changed the caller's slice: %v", edges)
+	}
+}
+
+func TestCutInvalid(t *testing.T) {
+	s := New([]float64{1, 2}, Float, "x")
+	tests := []struct {
+		name   string
+		s      Series
+		edges  []float64
+		labels []string
+		kind   ErrorKind
+	}{
+		{"string series", New([]string{"a"}, String, "x"), []float64{0, 1}, nil, ErrUnknownType},
+		{"one edge", s, []float64{1}, nil, ErrInvalidArgument},
+		{"not increasing", s, []float64{0, 2, 2}, nil, ErrInvalidArgument},
+		{"nan edge", s, []float64{0, math.NaN(), 5}, nil, ErrInvalidArgument},
+		{"nan first edge", s, []float64{math.NaN(), 5}, nil, ErrInvalidArgument},
+		{"label count", s, []float64{0, 1, 2}, []string{"a"}, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, used := Cut(tt.s, tt.edges, tt.labels, true, false)
+			if !errors.Is(got.Err, tt.kind) {
+				t.Errorf("err = %v, want %v", got.Err, tt.kind)
+			}
+			if used != nil {
+				t.Errorf("edges = %v, want nil", used)
+			}
+		})
+	}
+}
+
+func TestQCut(t *testing.T) {
+	s := New([]interface{}{1, 2, 3, 4, nil, 5, 6, 7, 8}, Int, "x")
+	got, edges := QCut(s, 4, []string{"q1", "q2", "q3", "q4"})
+	checkSeries(t, got, String, []string{"q1", "q1", "q2", "q2", "NaN", "q3", "q3", "q4", "q4"})
+	if want := []float64{1, 2, 4, 6, 8}; !reflect.DeepEqual(edges, want) {
+		t.Errorf("edges = %v, want %v", edges, want)
+	}
+
+	if got, _ := QCut(New([]int{1, 1, 1, 2}, Int, "x"), 4, nil); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("duplicate edges: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	if got, _ := QCut(s, 0, nil); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("q = 0: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	if got, _ := QCut(New([]interface{}{nil}, Float, "x"), 2, nil); !errors.Is(got.Err, ErrEmpty) {
+		t.Errorf("all NaN: err = %v, want ErrEmpty", got.Err)
+	}
+}
+
+func TestCutCategorical(t *testing.T) {
+	s := New([]int{70, 5, 30}, Int, "age")
+	got, _ := CutCategorical(s, []float64{0, 18, 65, 120}, []string{"child", "adult", "senior"}, true, true)
+	checkSeries(t, got, Categorical, []string{"senior", "child", "adult"})
+	if !got.Ordered() {
+		t.Error("categories should be ordered")
+	}
+	if want := []string{"child", "adult", "senior"}; !reflect.DeepEqual(got.Categories(), want) {
+		t.Errorf("categories = %v, want %v", got.Categories(), want)
+	}
+	if order := got.Order(false); !reflect.DeepEqual(order, []int{1, 2, 0}) {
+		t.Errorf("order = %v, want [1 2 0]", order)
+	}
+
+	q, _ := QCutCategorical(New([]int{4, 1, 3, 2}, Int, "x"), 2, nil)
+	checkSeries(t, q, Categorical, []string{"(2, 4]", "[1, 2]", "(2, 4]", "[1, 2]"})
+	if want := []string{"[1, 2]", "(2, 4]"}; !reflect.DeepEqual(q.Categories(), want) {
+		t.Errorf("categories = %v, want %v", q.Categories(), want)
+	}
+
+	bad, _ := CutCategorical(s, []float64{1}, nil, true, false)
+	if bad.Type() != Categorical || !errors.Is(bad.Err, ErrInvalidArgument) {
+		t.Errorf("invalid edges: type %v, err %v", bad.Type(), bad.Err)
+	}
+}
+
+func TestCutNumericTypes(t *testing.T) {
+	want := []string{"(0, 10]", "(10, 20]", "NaN"}
+	tests := []struct {
+		name string
+		s    Series
+	}{
+		{"int8", New([]interface{}{5, 15, nil}, Int8, "x")},
+		{"uint64", New([]interface{}{uint64(5), uint64(15), uint64(25)}, Uint64, "x")},
+		{"decimal", New([]string{"5.5", "10.01", "NaN"}, DecimalOf(6, 2), "x")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := Cut(tt.s, []float64{0, 10, 20}, nil, true, false)
+			checkSeries(t, got, String, want)
+		})
+	}
+
+	for _, s := range []Series{
+		New([]interface{}{1, 2, nil, 3, 4}, Int16, "x"),
+		New([]interface{}{uint64(1), uint64(2), nil, uint64(3), uint64(4)}, Uint64, "x"),
+		New([]string{"1", "2", "NaN", "3", "4"}, Decimal, "x"),
+	} {
+		t.Run("qcut "+string(s.Type()), func(t *testing.T) {
+			got, edges := QCut(s, 2, []string{"low", "high"})
+			checkSeries(t, got, String, []string{"low", "low", "NaN", "high", "high"})
+			if want := []float64{1, 2, 4}; !reflect.DeepEqual(edges, want) {
+				t.Errorf("edges = %v, want %v", edges, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package series
+
+import (
+	"math/big"
+)
+
+// RoundingMode 表示定点小数舍入的方式。
+type RoundingMode string
+
+// 支持的舍入方式
+const (
+	RoundHalfEven RoundingMode = "half_even" // 四舍六入五成双，DecimalOf 规格舍入时使用
+	RoundHalfUp   RoundingMode = "half_up"   // 四舍五入，0.5 远离零
+	RoundHalfDown RoundingMode = "half_down" // 0.5 趋向零，其余四舍五入
+	RoundUp       RoundingMode = "up"        // 远离零
+	RoundDown     RoundingMode = "down"      // 趋向零，即截断
+	RoundCeiling  RoundingMode = "ceiling"   // 趋向正无穷
+	RoundFloor    RoundingMode = "floor"     // 趋向负无穷
+)
+
+// DecimalAccessor 提供对 Decimal 类型 Series 的精确算术方法。
+// 运算结果为不固定小数位数的 Decimal Series，任一操作数为 NaN 时结果为 NaN。
+type DecimalAccessor struct {
+	series Series
+}
+
+// Dec 返回 Series 的定点小数算术方法集合。
+func (s Series) Dec() DecimalAccessor {
+	return DecimalAccessor{series: s}
+}
+
+// Add 逐个元素精确计算 s + x，结果的小数位数为两个操作数中较大的小数位数。
+// x 可以是与 s 长度相同的 Series 或切片，也可以是单个值，例如 "0.01"。
+func (a DecimalAccessor) Add(x interface{}) Series {
+	return a.binary("add", x, func(u, v *decimalElement) (*big.Int, int, error) {
+		p, q, scale := alignDecimals(u.v, u.scale, v.v, v.scale)
+		return new(big.Int).Add(p, q), scale, nil
+	})
+}
+
+// Sub 逐个元素精确计算 s - x，结果的小数位数为两个操作数中较大的小数位数。
+func (a DecimalAccessor) Sub(x interface{}) Series {
+	return a.binary("sub", x, func(u, v *decimalElement) (*big.Int, int, error) {
+		p, q, scale := alignDecimals(u.v, u.scale, v.v, v.scale)
+		return new(big.Int).Sub(p, q), scale, nil
+	})
+}
+
+// Mul 逐个元素精确计算 s × x，结果的小数位数为两个操作数的小数位数之和。
+func (a DecimalAccessor) Mul(x interface{}) Series {
+	return a.binary("mul", x, func(u, v *decimalElement) (*big.Int, int, error) {
+		return new(big.Int).Mul(u.v, v.v), u.scale + v.scale, nil
+	})
+}
+
+// Div 逐个元素计算 s ÷ x，结果保留 scale 位小数并按照 mode 舍入。除数为零时返回错误。
+func (a DecimalAccessor) Div(x interface{}, scale int, mode RoundingMode) Series {
+	if err := checkRounding(scale, mode); err != nil {
+		return a.failed("div", err)
+	}
+	return a.binary("div", x, func(u, v *decimalElement) (*big.Int, int, error) {
+		if v.v.Sign() == 0 {
+			return nil, 0, NewError(ErrInvalidArgument, "", "division_by_zero")
+		}
+		return divDecimal(u.v, u.scale, v.v, v.scale, scale, mode), scale, nil
+	})
+}
+
+// Round 将每个元素舍入为 scale 位小数，小数位数不足的元素补零。
+func (a DecimalAccessor) Round(scale int, mode RoundingMode) Series {
+	if err := a.check(); err != nil {
+		return a.failed("round", err)
+	}
+	if err := checkRounding(scale, mode); err != nil {
+		return a.failed("round", err)
+	}
+	elements := a.series.elements.(decimalElements)
+	ret := newDecimalElements(elements.Len(), freeDecimal)
+	for i, e := range elements.elements {
+		if e.IsNA() {
+			ret.elements[i].nan = true
+			continue
+		}
+		ret.elements[i].v, ret.elements[i].scale = rescale(e.v, e.scale, scale, mode), scale
+	}
+	return Series{Name: a.series.Name, elements: ret, t: Decimal}
+}
+
+// Sum 精确计算所有非 NaN 元素的和，小数位数为元素中最大的小数位数。没有非 NaN 元素时返回 0。
+func (a DecimalAccessor) Sum() (Element, error) {
+	if err := a.check(); err != nil {
+		return nil, WrapError("sum", err)
+	}
+	sum := &decimalElement{v: new(big.Int), spec: freeDecimal}
+	for _, e := range a.series.elements.(decimalElements).elements {
+		if e.IsNA() {
+			continue
+		}
+		p, q, scale := alignDecimals(sum.v, sum.scale, e.v, e.scale)
+		sum.v, sum.scale = new(big.Int).Add(p, q), scale
+	}
+	return sum, nil
+}
+
+// Mean 计算所有非 NaN 元素的平均值，求和是精确的，结果保留 scale 位小数并按照 mode 舍入。
+// 没有非 NaN 元素时返回 NaN 元素。
+func (a DecimalAccessor) Mean(scale int, mode RoundingMode) (Element, error) {
+	if err := checkRounding(scale, mode); err != nil {
+		return nil, WrapError("mean", err)
+	}
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, WrapError("mean", err)
+	}
+	n := 0
+	for _, e := range a.series.elements.(decimalElements).elements {
+		if !e.IsNA() {
+			n++
+		}
+	}
+	if n == 0 {
+		return &decimalElement{nan: true, spec: freeDecimal}, nil
+	}
+	s := sum.(*decimalElement)
+	v := divDecimal(s.v, s.scale, big.NewInt(int64(n)), 0, scale, mode)
+	return &decimalElement{v: v, scale: scale, spec: freeDecimal}, nil
+}
+
+// AddE 与 Add 相同，但返回错误。
+func (a DecimalAccessor) AddE(x interface{}) (Series, error) {
+	return checkedSeries(a.Add(x))
+}
+
+// SubE 与 Sub 相同，但返回错误。
+func (a DecimalAccessor) SubE(x interface{}) (Series, error) {
+	return checkedSeries(a.Sub(x))
+}
+
+// MulE 与 Mul 相同，但返回错误。
+func (a DecimalAccessor) MulE(x interface{}) (Series, error) {
+	return checkedSeries(a.Mul(x))
+}
+
+// DivE 与 Div 相同，但返回错误。
+func (a DecimalAccessor) DivE(x interface{}, scale int, mode RoundingMode) (Series, error) {
+	return checkedSeries(a.Div(x, scale, mode))
+}
+
+// RoundE 与 Round 相同，但返回错误。
+func (a DecimalAccessor) RoundE(scale int, mode RoundingMode) (Series, error) {
+	return checkedSeries(a.Round(scale, mode))
+}
+
+// divDecimal 计算 u × 10^-us ÷ (v × 10^-vs)，返回保留 scale 位小数并按照 mode 舍入的整数值。v 不能为零。
+func divDecimal(u *big.Int, us int, v *big.Int, vs int, scale int, mode RoundingMode) *big.Int {
+	// 结果的整数值为 u × 10^(vs+scale) ÷ (v × 10^us)
+	num := new(big.Int).Mul(u, pow10(vs+scale))
+	den := new(big.Int).Mul(v, pow10(us))
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	return roundQuo(num, den, mode)
+}
+
+// binary 对 s 和 x 的每对元素应用 f，x 只有一个元素时与 s 的每个元素运算。
+func (a DecimalAccessor) binary(op string, x interface{}, f func(u, v *decimalElement) (*big.Int, int, error)) Series {
+	if err := a.check(); err != nil {
+		return a.failed(op, err)
+	}
+	y := New(x, Decimal, "")
+	if y.Err != nil {
+		return a.failed(op, NewError(ErrInvalidArgument, "", "argument_has_errors").Wrap(y.Err))
+	}
+	n := a.series.Len()
+	if y.Len() != 1 && y.Len() != n {
+		return a.failed(op, NewError(ErrDimensionMismatch, "", "compare_length_mismatch"))
+	}
+	elements := a.series.elements.(decimalElements)
+	other := y.elements.(decimalElements)
+	ret := newDecimalElements(n, freeDecimal)
+	for i := range elements.elements {
+		u, v := &elements.elements[i], &other.elements[0]
+		if other.Len() > 1 {
+			v = &other.elements[i]
+		}
+		if u.IsNA() || v.IsNA() {
+			ret.elements[i].nan = true
+			continue
+		}
+		value, scale, err := f(u, v)
+		if err != nil {
+			return a.failed(op, err)
+		}
+		ret.elements[i].v, ret.elements[i].scale = value, scale
+	}
+	return Series{Name: a.series.Name, elements: ret, t: Decimal}
+}
+
+// checkRounding 检查小数位数和舍入方式是否有效。
+func checkRounding(scale int, mode RoundingMode) error {
+	if scale < 0 {
+		return NewError(ErrInvalidArgument, "", "negative_scale", scale)
+	}
+	if scale > maxDecimalScale {
+		return NewError(ErrInvalidArgument, "", "scale_too_large", scale, maxDecimalScale)
+	}
+	switch mode {
+	case RoundHalfEven, RoundHalfUp, RoundHalfDown, RoundUp, RoundDown, RoundCeiling, RoundFloor:
+		return nil
+	}
+	return NewError(ErrInvalidArgument, "", "unknown_rounding", mode)
+}
+
+// check 检查 Series 是否可以进行定点小数运算。
+func (a DecimalAccessor) check() error {
+	if a.series.Err != nil {
+		return a.series.Err
+	}
+	if a.series.t != Decimal {
+		return NewError(ErrUnknownType, "", "unsupported_type", a.series.t)
+	}
+	return nil
+}
+
+// failed 返回带有错误信息的空 Series。
+func (a DecimalAccessor) failed(op string, err error) Series {
+	ret := New([]string{}, Decimal, a.series.Name)
+	ret.Err = WrapError(op, err)
+	return ret
+}
@@ -0,0 +1,120 @@
+package series
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorKind 表示错误的类别。可以使用 errors.Is 判断一个错误是否属于某个类别，例如：
+//
+//	if errors.Is(df.Err, series.ErrColumnNotFound) { ... }
+type ErrorKind string
+
+// 支持的错误类别
+const (
+	ErrColumnNotFound    ErrorKind = "column_not_found"   // 找不到列
+	ErrDimensionMismatch ErrorKind = "dimension_mismatch" // 维度不匹配
+	ErrUnknownType       ErrorKind = "unknown_type"       // 类型未知或不受支持
+	ErrIndexOutOfRange   ErrorKind = "index_out_of_range" // 索引超出范围
+	ErrInvalidIndex      ErrorKind = "invalid_index"      // 索引无效
+	ErrUnknownComparator ErrorKind = "unknown_comparator" // 比较器未知
+	ErrConversion        ErrorKind = "conversion"         // 类型转换失败
+	ErrEmpty             ErrorKind = "empty"              // 输入为空
+	ErrInvalidArgument   ErrorKind = "invalid_argument"   // 参数无效
+)
+
+// Error 返回错误类别在当前语言下的描述。
+func (k ErrorKind) Error() string {
+	return Message(string(k))
+}
+
+// Error 是带有上下文信息的错误。错误信息使用当前语言生成，可以通过 SetLocale 切换。
+// Error 属于其 Kind 表示的类别，并包装了引起错误的原因 Err。
+type Error struct {
+	Kind   ErrorKind     // 错误类别
+	Op     string        // 发生错误的操作
+	Column string        // 相关的列名
+	Msg    string        // 消息编号，为空时使用 Kind 的描述
+	Args   []interface{} // 消息参数
+	Err    error         // 引起错误的原因
+}
+
+// NewError 创建一个类别为 kind 的错误，msg 为消息编号，args 为消息参数。
+func NewError(kind ErrorKind, op, msg string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Op: op, Msg: msg, Args: args}
+}
+
+// ColumnNotFoundError 创建一个表示找不到列 column 的错误。
+func ColumnNotFoundError(op, column string) *Error {
+	return &Error{
+		Kind:   ErrColumnNotFound,
+		Op:     op,
+		Column: column,
+		Msg:    "column_not_found_name",
+		Args:   []interface{}{column},
+	}
+}
+
+// WrapError 使用操作名称 op 包装错误 err。如果 err 带有错误类别，则新错误沿用该类别。
+func WrapError(op string, err error) *Error {
+	ret := &Error{Op: op, Err: err}
+	var e *Error
+	if errors.As(err, &e) {
+		ret.Kind = e.Kind
+		ret.Column = e.Column
+	}
+	return ret
+}
+
+// WithColumn 设置错误相关的列名并返回错误本身。
+func (e *Error) WithColumn(column string) *Error {
+	e.Column = column
+	return e
+}
+
+// Wrap 设置引起错误的原因并返回错误本身。
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Error 实现 error 接口，格式为 "操作: 消息: 原因"。
+func (e *Error) Error() string {
+	var parts []string
+	if e.Op != "" {
+		parts = append(parts, e.Op)
+	}
+	switch {
+	case e.Msg != "":
+		parts = append(parts, Message(e.Msg, e.Args...))
+	case e.Err == nil && e.Kind != "":
+		parts = append(parts, e.Kind.Error())
+	}
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+	return strings.Join(parts, ": ")
+}
+
+// Is 判断错误是否属于类别 target。
+func (e *Error) Is(target error) bool {
+	k, ok := target.(ErrorKind)
+	return ok && k != "" && k == e.Kind
+}
+
+// Unwrap 返回引起错误的原因。
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Operations 返回错误链中记录的操作名称，从最外层的操作开始，可以用于定位长调用链中失败的步骤。
+func Operations(err error) []string {
+	var ops []string
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Op != "" {
+			ops = append(ops, e.Op)
+		}
+		err = errors.Unwrap(err)
+	}
+	return ops
+}
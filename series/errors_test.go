@@ -0,0 +1,81 @@
+package series
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	err := WrapError("outer", NewError(ErrConversion, "inner", "convert_nan", "int").WithColumn("x"))
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("errors.Is(%v, ErrConversion) = false", err)
+	}
+	if errors.Is(err, ErrColumnNotFound) {
+		t.Errorf("errors.Is(%v, ErrColumnNotFound) = true", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.Column != "x" || e.Op != "outer" {
+		t.Errorf("errors.As = %+v", e)
+	}
+	if got, want := Operations(err), []string{"outer", "inner"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("operations = %v, want %v", got, want)
+	}
+	if nf := ColumnNotFoundError("Col", "age"); !errors.Is(nf, ErrColumnNotFound) || nf.Column != "age" {
+		t.Errorf("ColumnNotFoundError = %+v", nf)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	defer SetLocale("zh")
+	err := ColumnNotFoundError("Col", "age")
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"zh", `Col: 无法找到列名 "age"`},
+		{"en", `Col: column "age" not found`},
+	}
+	for _, tt := range tests {
+		if err := SetLocale(tt.locale); err != nil {
+			t.Fatal(err)
+		}
+		if got := err.Error(); got != tt.want {
+			t.Errorf("%s: message = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+	if err := SetLocale("xx"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown locale: err = %v, want ErrInvalidArgument", err)
+	}
+	if Locale() != "en" {
+		t.Errorf("locale = %q after failed SetLocale, want en", Locale())
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	defer SetLocale("zh")
+	RegisterLocale("test", map[string]string{"empty": "nothing here"})
+	if err := SetLocale("test"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ErrEmpty.Error(); got != "nothing here" {
+		t.Errorf("registered message = %q", got)
+	}
+	// 缺少的消息回退到中文
+	if got := ErrConversion.Error(); got != zhMessages["conversion"] {
+		t.Errorf("fallback message = %q, want %q", got, zhMessages["conversion"])
+	}
+}
+
+func TestLocaleMessagesComplete(t *testing.T) {
+	for id := range zhMessages {
+		if _, ok := enMessages[id]; !ok {
+			t.Errorf("message %q has no English translation", id)
+		}
+	}
+	for id := range enMessages {
+		if _, ok := zhMessages[id]; !ok {
+			t.Errorf("message %q has no Chinese translation", id)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package series
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Scalar 是 Of、OfNullable 和 Values 支持的元素类型。int 对应 Int 类型，int64 对应 Int64 类型，
+// float64 对应 Float 类型，string 对应 String 类型，bool 对应 Bool 类型，time.Time 以 RFC 3339 格式的字符串
+// 保存在 String 类型中。
+type Scalar interface {
+	int | int64 | float64 | string | bool | time.Time
+}
+
+// Of 使用 values 创建一个 Series，类型由 T 确定，在编译时检查值的类型。
+// 与 New 一样，字符串 "NaN" 和浮点数 NaN 被视为 NaN。
+func Of[T Scalar](values []T, name string) Series {
+	ret := Series{Name: name}
+	switch v := any(values).(type) {
+	case []int:
+		elems := make(intElements, len(v))
+		for i, x := range v {
+			elems[i] = intElement{e: x}
+		}
+		ret.elements, ret.t = elems, Int
+	case []int64:
+		elems := newSizedIntElements(len(v), intKinds[Int64])
+		for i, x := range v {
+			elems[i].v = uint64(x)
+		}
+		ret.elements, ret.t = elems, Int64
+	case []float64:
+		elems := make(floatElements, len(v))
+		for i, x := range v {
+			elems[i] = floatElement{e: x, nan: math.IsNaN(x)}
+		}
+		ret.elements, ret.t = elems, Float
+	case []string:
+		elems := make(stringElements, len(v))
+		for i, x := range v {
+			elems[i] = stringElement{e: x, nan: x == "NaN"}
+		}
+		ret.elements, ret.t = elems, String
+	case []bool:
+		elems := make(boolElements, len(v))
+		for i, x := range v {
+			elems[i] = boolElement{e: x}
+		}
+		ret.elements, ret.t = elems, Bool
+	case []time.Time:
+		elems := make(stringElements, len(v))
+		for i, x := range v {
+			elems[i] = stringElement{e: x.Format(time.RFC3339Nano)}
+		}
+		ret.elements, ret.t = elems, String
+	}
+	return ret
+}
+
+// OfNullable 与 Of 相同，但 values 中的 nil 指针被视为 NaN。
+func OfNullable[T Scalar](values []*T, name string) Series {
+	vals := make([]T, len(values))
+	for i, p := range values {
+		if p != nil {
+			vals[i] = *p
+		}
+	}
+	ret := Of(vals, name)
+	for i, p := range values {
+		if p != nil {
+			continue
+		}
+		switch elems := ret.elements.(type) {
+		case intElements:
+			elems[i].nan = true
+		case sizedIntElements:
+			elems[i].nan = true
+		case floatElements:
+			elems[i].nan = true
+		case stringElements:
+			elems[i].nan = true
+		case boolElements:
+			elems[i].nan = true
+		}
+	}
+	return ret
+}
+
+// Values 以 []T 返回 Series 的值，valid 中的元素表示对应的值是否不是 NaN，NaN 对应 T 的零值。
+// Series 的类型与 T 不对应时，逐个转换元素，任一元素无法转换时返回错误。
+func Values[T Scalar](s Series) (values []T, valid []bool, err error) {
+	if s.Err != nil {
+		return nil, nil, s.Err
+	}
+	n := s.Len()
+	values = make([]T, n)
+	valid = make([]bool, n)
+	out := any(values)
+
+	// 类型对应时直接读取元素，避免通过 interface{} 转换
+	switch elems := s.elements.(type) {
+	case intElements:
+		switch v := out.(type) {
+		case []int:
+			for i, e := range elems {
+				if valid[i] = !e.nan; valid[i] {
+					v[i] = e.e
+				}
+			}
+			return values, valid, nil
+		case []int64:
+			for i, e := range elems {
+				if valid[i] = !e.nan; valid[i] {
+					v[i] = int64(e.e)
+				}
+			}
+			return values, valid, nil
+		}
+	case sizedIntElements:
+		if v, ok := out.([]int64); ok && s.t == Int64 {
+			for i, e := range elems {
+				if valid[i] = !e.IsNA(); valid[i] {
+					v[i] = int64(e.v)
+				}
+			}
+			return values, valid, nil
+		}
+	case floatElements:
+		if v, ok := out.([]float64); ok {
+			for i, e := range elems {
+				if valid[i] = !e.IsNA(); valid[i] {
+					v[i] = e.e
+				}
+			}
+			return values, valid, nil
+		}
+	case stringElements:
+		if v, ok := out.([]string); ok {
+			for i, e := range elems {
+				if valid[i] = !e.nan; valid[i] {
+					v[i] = e.e
+				}
+			}
+			return values, valid, nil
+		}
+	case boolElements:
+		if v, ok := out.([]bool); ok {
+			for i, e := range elems {
+				if valid[i] = !e.nan; valid[i] {
+					v[i] = e.e
+				}
+			}
+			return values, valid, nil
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		valid[i] = true
+		var err error
+		switch v := out.(type) {
+		case []int:
+			var x int64
+			if x, err = ElementInt64(e); err == nil && int64(int(x)) != x {
+				err = NewError(ErrConversion, "", "convert_value", e.Type(), e.String(), "int")
+			}
+			v[i] = int(x)
+		case []int64:
+			v[i], err = ElementInt64(e)
+		case []float64:
+			if s.t == String || s.t == Categorical {
+				v[i], err = strconv.ParseFloat(e.String(), 64)
+			} else {
+				v[i] = e.Float()
+			}
+		case []string:
+			v[i] = e.String()
+		case []bool:
+			v[i], err = e.Bool()
+		case []time.Time:
+			v[i], err = time.Parse(time.RFC3339Nano, e.String())
+		}
+		if err != nil {
+			// 元素方法返回的错误与这里的消息相同，只保留其原因
+			if ce, ok := err.(*Error); ok {
+				err = ce.Err
+			}
+			var zero T
+			return nil, nil, NewError(ErrConversion, "Values", "convert_value", s.t, e.String(), typeName(zero)).WithColumn(s.Name).Wrap(err)
+		}
+	}
+	return values, valid, nil
+}
+
+// typeName 返回 Scalar 类型的名称。
+func typeName(v any) string {
+	switch v.(type) {
+	case int:
+		return "int"
+	case int64:
+		return "int64"
+	case float64:
+		return "float64"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	}
+	return "time.Time"
+}
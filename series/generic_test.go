@@ -0,0 +1,137 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOf(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		s    Series
+		typ  Type
+		want []string
+	}{
+		{"int", Of([]int{1, -2}, "x"), Int, []string{"1", "-2"}},
+		{"int64", Of([]int64{math.MaxInt64, math.MinInt64}, "x"), Int64, []string{"9223372036854775807", "-9223372036854775808"}},
+		{"float", Of([]float64{1.5, math.NaN()}, "x"), Float, []string{"1.500000", "NaN"}},
+		{"string", Of([]string{"a", "NaN"}, "x"), String, []string{"a", "NaN"}},
+		{"bool", Of([]bool{true, false}, "x"), Bool, []string{"true", "false"}},
+		{"time", Of([]time.Time{ts}, "x"), String, []string{"2024-01-02T03:04:05Z"}},
+		{"empty", Of([]int{}, "x"), Int, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.s, tt.typ, tt.want)
+			if tt.s.Name != "x" {
+				t.Errorf("name = %q, want %q", tt.s.Name, "x")
+			}
+		})
+	}
+	if !Of([]string{"a", "NaN"}, "").Elem(1).IsNA() {
+		t.Error(`"NaN" string is not NA`)
+	}
+}
+
+func TestOfNullable(t *testing.T) {
+	one, two := 1, 2
+	checkSeries(t, OfNullable([]*int{&one, nil, &two}, ""), Int, []string{"1", "NaN", "2"})
+	a := "a"
+	checkSeries(t, OfNullable([]*string{nil, &a}, ""), String, []string{"NaN", "a"})
+	f := 0.5
+	checkSeries(t, OfNullable([]*float64{&f, nil}, ""), Float, []string{"0.500000", "NaN"})
+	b := false
+	checkSeries(t, OfNullable([]*bool{nil, &b}, ""), Bool, []string{"NaN", "false"})
+}
+
+func TestValues(t *testing.T) {
+	s := New([]interface{}{1, nil, 3}, Int, "x")
+	ints, valid, err := Values[int](s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ints, []int{1, 0, 3}) || !reflect.DeepEqual(valid, []bool{true, false, true}) {
+		t.Errorf("Values[int] = %v, %v", ints, valid)
+	}
+
+	floats, _, err := Values[float64](s)
+	if err != nil || !reflect.DeepEqual(floats, []float64{1, 0, 3}) {
+		t.Errorf("Values[float64] = %v, %v", floats, err)
+	}
+
+	strs, _, err := Values[string](New([]float64{1.5}, Float, ""))
+	if err != nil || !reflect.DeepEqual(strs, []string{"1.500000"}) {
+		t.Errorf("Values[string] = %v, %v", strs, err)
+	}
+
+	i64, _, err := Values[int64](New([]float64{-4}, Float, ""))
+	if err != nil || !reflect.DeepEqual(i64, []int64{-4}) {
+		t.Errorf("Values[int64] = %v, %v", i64, err)
+	}
+
+	parsed, _, err := Values[float64](New([]string{"2.5"}, String, ""))
+	if err != nil || !reflect.DeepEqual(parsed, []float64{2.5}) {
+		t.Errorf("Values[float64] of strings = %v, %v", parsed, err)
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	times, _, err := Values[time.Time](Of([]time.Time{ts}, ""))
+	if err != nil || !times[0].Equal(ts) {
+		t.Errorf("Values[time.Time] = %v, %v", times, err)
+	}
+}
+
+func TestValuesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func() error
+		kind ErrorKind
+	}{
+		{"fraction", func() error { _, _, err := Values[int](New([]float64{1.5}, Float, "")); return err }, ErrConversion},
+		{"string to int", func() error { _, _, err := Values[int](New([]string{"a"}, String, "")); return err }, ErrConversion},
+		{"string to bool", func() error { _, _, err := Values[bool](New([]string{"a"}, String, "")); return err }, ErrConversion},
+		{"bad time", func() error { _, _, err := Values[time.Time](New([]string{"a"}, String, "")); return err }, ErrConversion},
+		{"series error", func() error {
+			_, _, err := Values[int](Series{Err: NewError(ErrEmpty, "", "empty_series")})
+			return err
+		}, ErrEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f(); !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestInt64RoundTrip(t *testing.T) {
+	in := []int64{math.MaxInt64, math.MinInt64, 0, 1<<53 + 1}
+	s := Of(in, "x")
+	out, valid, err := Values[int64](s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) || !reflect.DeepEqual(valid, []bool{true, true, true, true}) {
+		t.Errorf("Values[int64] = %v, %v; want %v", out, valid, in)
+	}
+
+	x := int64(math.MinInt64)
+	checkSeries(t, OfNullable([]*int64{&x, nil}, ""), Int64, []string{"-9223372036854775808", "NaN"})
+
+	u, _, err := Values[int64](New([]interface{}{uint64(7)}, Uint64, ""))
+	if err != nil || !reflect.DeepEqual(u, []int64{7}) {
+		t.Errorf("Values[int64] of Uint64 = %v, %v", u, err)
+	}
+	if _, _, err := Values[int64](New([]interface{}{uint64(math.MaxUint64)}, Uint64, "")); !errors.Is(err, ErrConversion) {
+		t.Errorf("Values[int64] overflow err = %v, want %v", err, ErrConversion)
+	}
+	if _, _, err := Values[int](Of([]int64{math.MaxInt64}, "")); strconv.IntSize == 32 && !errors.Is(err, ErrConversion) {
+		t.Errorf("Values[int] overflow err = %v, want %v", err, ErrConversion)
+	}
+}
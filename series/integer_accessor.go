@@ -0,0 +1,171 @@
+package series
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// IntegerAccessor 提供对整数类型 Series 的算术方法，结果超出 Series 类型的范围时返回错误，而不是回绕。
+// 支持 Int 和固定位数的整数类型，结果的类型与 Series 相同，任一操作数为 NaN 时结果为 NaN。
+type IntegerAccessor struct {
+	series Series
+}
+
+// Integer 返回 Series 的整数算术方法集合。
+func (s Series) Integer() IntegerAccessor {
+	return IntegerAccessor{series: s}
+}
+
+// Add 逐个元素计算 s + x。x 可以是与 s 长度相同的 Series 或切片，也可以是单个值。
+func (a IntegerAccessor) Add(x interface{}) Series {
+	return a.binary("add", x, func(u, v *big.Int) *big.Int { return new(big.Int).Add(u, v) })
+}
+
+// Sub 逐个元素计算 s - x。
+func (a IntegerAccessor) Sub(x interface{}) Series {
+	return a.binary("sub", x, func(u, v *big.Int) *big.Int { return new(big.Int).Sub(u, v) })
+}
+
+// Mul 逐个元素计算 s × x。
+func (a IntegerAccessor) Mul(x interface{}) Series {
+	return a.binary("mul", x, func(u, v *big.Int) *big.Int { return new(big.Int).Mul(u, v) })
+}
+
+// Sum 计算所有非 NaN 元素的和，结果为与 Series 类型相同的元素，超出范围时返回错误。没有非 NaN 元素时返回 0。
+func (a IntegerAccessor) Sum() (Element, error) {
+	kind, err := a.check()
+	if err != nil {
+		return nil, WrapError("sum", err)
+	}
+	sum := new(big.Int)
+	for i := 0; i < a.series.Len(); i++ {
+		e := a.series.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		sum.Add(sum, integerValue(e))
+	}
+	if !kind.fitsBig(sum) {
+		return nil, NewError(ErrConversion, "sum", "int_overflow", sum, kind.t)
+	}
+	return New([]interface{}{kind.value(sum)}, kind.t, "").Elem(0), nil
+}
+
+// AddE 与 Add 相同，但返回错误。
+func (a IntegerAccessor) AddE(x interface{}) (Series, error) {
+	return checkedSeries(a.Add(x))
+}
+
+// SubE 与 Sub 相同，但返回错误。
+func (a IntegerAccessor) SubE(x interface{}) (Series, error) {
+	return checkedSeries(a.Sub(x))
+}
+
+// MulE 与 Mul 相同，但返回错误。
+func (a IntegerAccessor) MulE(x interface{}) (Series, error) {
+	return checkedSeries(a.Mul(x))
+}
+
+// binary 对 s 和 x 的每对元素应用 f，x 只有一个元素时与 s 的每个元素运算。
+func (a IntegerAccessor) binary(op string, x interface{}, f func(u, v *big.Int) *big.Int) Series {
+	kind, err := a.check()
+	if err != nil {
+		return a.failed(op, err)
+	}
+	y := New(x, kind.t, "")
+	if y.Err != nil {
+		return a.failed(op, NewError(ErrInvalidArgument, "", "argument_has_errors").Wrap(y.Err))
+	}
+	n := a.series.Len()
+	if y.Len() != 1 && y.Len() != n {
+		return a.failed(op, NewError(ErrDimensionMismatch, "", "compare_length_mismatch"))
+	}
+	values := make([]interface{}, n)
+	for i := range values {
+		u, v := a.series.elements.Elem(i), y.elements.Elem(0)
+		if y.Len() > 1 {
+			v = y.elements.Elem(i)
+		}
+		if u.IsNA() || v.IsNA() {
+			continue
+		}
+		r := f(integerValue(u), integerValue(v))
+		if !kind.fitsBig(r) {
+			return a.failed(op, NewError(ErrConversion, "", "int_overflow", r, kind.t))
+		}
+		values[i] = kind.value(r)
+	}
+	return New(values, kind.t, a.series.Name)
+}
+
+// integerValue 返回非 NaN 整数元素的值。
+func integerValue(e Element) *big.Int {
+	if s, ok := e.(*sizedIntElement); ok {
+		return s.big()
+	}
+	i, _ := e.Int()
+	return big.NewInt(int64(i))
+}
+
+// ElementInt64 返回元素的 int64 值。整数类型的元素直接使用其值而不经过 int，浮点数必须没有小数部分，
+// 其他类型的元素使用 Int 方法转换。NaN、有小数部分或超出 int64 范围的值返回错误。
+func ElementInt64(e Element) (int64, error) {
+	if e.IsNA() {
+		return 0, NewError(ErrConversion, "", "convert_nan", "int64")
+	}
+	switch t := e.Type(); {
+	case IsInteger(t):
+		if v := integerValue(e); v.IsInt64() {
+			return v.Int64(), nil
+		}
+	case t == Float:
+		// float64(math.MaxInt64) 等于 2^63，不在 int64 的范围内
+		if x := e.Float(); x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x), nil
+		}
+	default:
+		i, err := e.Int()
+		return int64(i), err
+	}
+	return 0, NewError(ErrConversion, "", "convert_value", e.Type(), e.String(), "int64")
+}
+
+// intKindOf 返回整数类型 t 的描述，Int 的位数与平台的 int 相同。
+func intKindOf(t Type) (*intKind, bool) {
+	if t == Int {
+		return &intKind{Int, strconv.IntSize, true}, true
+	}
+	kind, ok := intKinds[t]
+	return kind, ok
+}
+
+// value 返回在类型范围内的整数 v 对应的、可以设置为元素的值。
+func (k *intKind) value(v *big.Int) interface{} {
+	switch {
+	case k.t == Int:
+		return int(v.Int64())
+	case k.signed:
+		return v.Int64()
+	}
+	return v.Uint64()
+}
+
+// check 检查 Series 是否可以进行整数运算，并返回其类型的描述。
+func (a IntegerAccessor) check() (*intKind, error) {
+	if a.series.Err != nil {
+		return nil, a.series.Err
+	}
+	kind, ok := intKindOf(a.series.t)
+	if !ok {
+		return nil, NewError(ErrUnknownType, "", "unsupported_type", a.series.t)
+	}
+	return kind, nil
+}
+
+// failed 返回带有错误信息的空 Series。
+func (a IntegerAccessor) failed(op string, err error) Series {
+	ret := a.series.Empty()
+	ret.Err = WrapError(op, err)
+	return ret
+}
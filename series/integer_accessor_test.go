@@ -0,0 +1,131 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestElementInt64(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Series
+		want int64
+		ok   bool
+	}{
+		{"int", New([]int{-7}, Int, ""), -7, true},
+		{"int64 max", New([]interface{}{int64(math.MaxInt64)}, Int64, ""), math.MaxInt64, true},
+		{"uint64 max", New([]interface{}{uint64(math.MaxUint64)}, Uint64, ""), 0, false},
+		{"float", New([]float64{3}, Float, ""), 3, true},
+		{"float fraction", New([]float64{3.5}, Float, ""), 0, false},
+		{"float 2^63", New([]float64{1 << 63}, Float, ""), 0, false},
+		{"float -2^63", New([]float64{-1 << 63}, Float, ""), math.MinInt64, true},
+		{"string", New([]string{"12"}, String, ""), 12, true},
+		{"bool", New([]bool{true}, Bool, ""), 1, true},
+		{"nan", New([]interface{}{nil}, Int, ""), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ElementInt64(tt.s.Elem(0))
+			if (err == nil) != tt.ok || got != tt.want {
+				t.Errorf("ElementInt64 = %v, %v; want %v, ok %v", got, err, tt.want, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrConversion) {
+				t.Errorf("err = %v, want %v", err, ErrConversion)
+			}
+		})
+	}
+}
+
+func TestSizedIntConversionErrors(t *testing.T) {
+	i8 := New([]int{1, 2}, Int8, "")
+	tests := []struct {
+		name string
+		got  Series
+		msg  string
+	}{
+		{"new fraction", New([]float64{1.5, 300}, Int8, ""), "int_fraction"},
+		{"new range", New([]float64{1, 300}, Int8, ""), "int_range"},
+		{"new float32 fraction", New([]float32{2, 0.5}, Int16, ""), "int_fraction"},
+		{"new float series", New(New([]float64{0.25}, Float, ""), Uint8, ""), "int_fraction"},
+		{"set fraction", i8.Copy().Set(0, New([]float64{2.5}, Float, "")), "int_fraction"},
+		{"append fraction", func() Series { c := i8.Copy(); c.Append([]float64{3.5}); return c }(), "int_fraction"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *Error
+			if !errors.As(tt.got.Err, &e) || e.Kind != ErrConversion || e.Msg != tt.msg {
+				t.Errorf("err = %v, want %v with message %q", tt.got.Err, ErrConversion, tt.msg)
+			}
+		})
+	}
+
+	ok := New([]interface{}{2.0, float32(-3), math.NaN(), nil}, Int8, "")
+	if ok.Err != nil {
+		t.Fatalf("err = %v", ok.Err)
+	}
+	checkSeries(t, ok, Int8, []string{"2", "-3", "NaN", "NaN"})
+}
+
+func TestIntegerAccessor(t *testing.T) {
+	i8 := New([]interface{}{100, nil, -100}, Int8, "a")
+	tests := []struct {
+		name string
+		got  Series
+		typ  Type
+		want []string
+	}{
+		{"add scalar", i8.Integer().Add(27), Int8, []string{"127", "NaN", "-73"}},
+		{"sub slice", i8.Integer().Sub([]int{1, 2, 28}), Int8, []string{"99", "NaN", "-128"}},
+		{"mul", New([]int{3, 4}, Uint16, "").Integer().Mul(1000), Uint16, []string{"3000", "4000"}},
+		{"int64 exact", New([]int64{math.MaxInt64 - 1}, Int64, "").Integer().Add(1), Int64, []string{"9223372036854775807"}},
+		{"int", New([]int{2}, Int, "").Integer().Mul(3), Int, []string{"6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.got, tt.typ, tt.want)
+		})
+	}
+
+	sum, err := New([]interface{}{100, nil, 27}, Int8, "").Integer().Sum()
+	if err != nil || sum.String() != "127" || sum.Type() != Int8 {
+		t.Errorf("Sum = %v (%v), %v", sum, sum.Type(), err)
+	}
+	if _, err := New([]int{100, 28}, Int8, "").Integer().Sum(); !errors.Is(err, ErrConversion) {
+		t.Errorf("Sum overflow err = %v, want %v", err, ErrConversion)
+	}
+}
+
+func TestIntegerAccessorErrors(t *testing.T) {
+	i8 := New([]int{100, -100}, Int8, "a")
+	tests := []struct {
+		name string
+		f    func() (Series, error)
+		kind ErrorKind
+	}{
+		{"add overflow", func() (Series, error) { return i8.Integer().AddE(28) }, ErrConversion},
+		{"sub overflow", func() (Series, error) { return i8.Integer().SubE(29) }, ErrConversion},
+		{"mul overflow", func() (Series, error) { return i8.Integer().MulE(2) }, ErrConversion},
+		{"uint negative", func() (Series, error) { return New([]int{1}, Uint32, "").Integer().SubE(2) }, ErrConversion},
+		{"operand out of range", func() (Series, error) { return i8.Integer().AddE(1000) }, ErrInvalidArgument},
+		{"length", func() (Series, error) { return i8.Integer().AddE([]int{1, 2, 3}) }, ErrDimensionMismatch},
+		{"not integer", func() (Series, error) { return New([]float64{1}, Float, "").Integer().AddE(1) }, ErrUnknownType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.f()
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+			if s.Err != err {
+				t.Errorf("Series.Err = %v, want the returned error", s.Err)
+			}
+		})
+	}
+
+	s, err := i8.Integer().AddE(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSeries(t, s, Int8, []string{"101", "-99"})
+}
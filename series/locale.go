@@ -0,0 +1,270 @@
+package series
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	localeMu sync.RWMutex
+	locale   = "zh"
+	locales  = map[string]map[string]string{
+		"zh": zhMessages,
+		"en": enMessages,
+	}
+)
+
+// RegisterLocale 注册或补充名为 name 的语言的消息。messages 的键为消息编号，值为 fmt 格式的消息模板。
+// 缺少的消息会回退到中文消息。
+func RegisterLocale(name string, messages map[string]string) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	m, ok := locales[name]
+	if !ok {
+		m = make(map[string]string, len(messages))
+		locales[name] = m
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// SetLocale 设置错误信息和标签使用的语言，内置支持 "zh" 和 "en"，默认为 "zh"。
+func SetLocale(name string) error {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	if _, ok := locales[name]; !ok {
+		return NewError(ErrInvalidArgument, "SetLocale", "unknown_locale", name)
+	}
+	locale = name
+	return nil
+}
+
+// Locale 返回当前使用的语言。
+func Locale() string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return locale
+}
+
+// Message 返回编号为 id 的消息在当前语言下的文本，args 为消息参数。
+// 当前语言中没有该消息时回退到中文，仍然没有时返回 id 本身。
+func Message(id string, args ...interface{}) string {
+	localeMu.RLock()
+	tmpl, ok := locales[locale][id]
+	if !ok {
+		tmpl, ok = locales["zh"][id]
+	}
+	localeMu.RUnlock()
+	if !ok {
+		tmpl = id
+	}
+	if len(args) == 0 {
+		return tmpl
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
+
+// zhMessages 是中文消息。
+var zhMessages = map[string]string{
+	// 错误类别
+	"column_not_found":   "无法找到列名",
+	"dimension_mismatch": "维度不匹配",
+	"unknown_type":       "不支持的类型",
+	"index_out_of_range": "索引超出范围",
+	"invalid_index":      "索引错误",
+	"unknown_comparator": "未知比较器",
+	"conversion":         "类型转换失败",
+	"empty":              "输入为空",
+	"invalid_argument":   "参数错误",
+
+	// series
+	"unknown_locale":           "未知语言 %q",
+	"column_not_found_name":    "无法找到列名 %q",
+	"unsupported_type":         "不支持的类型 %v",
+	"unknown_type_name":        "未知类型 %v",
+	"argument_has_errors":      "参数存在错误",
+	"input_has_errors":         "输入存在错误",
+	"index_dimension_mismatch": "索引维度不匹配",
+	"index_has_nan":            "索引包含 NaN",
+	"unknown_index_mode":       "未知索引模式",
+	"unknown_comparator_name":  "未知比较器 %v",
+	"compare_length_mismatch":  "长度不匹配",
+	"comp_func_type":           "comparando 不是一个 func(el Element) bool 类型的比较函数",
+	"slice_out_of_bounds":      "切片索引超出范围",
+	"convert_nan":              "无法将 NaN 转换为 %s",
+	"convert_inf":              "无法将 Inf 转换为 %s",
+	"convert_value":            "无法将 %s %q 转换为 %s",
+	"min_edges":                "至少需要两个区间边界",
+	"edges_increasing":         "区间边界必须严格递增",
+	"edges_nan":                "区间边界不能为 NaN",
+	"labels_count":             "标签数量必须比区间边界少一个",
+	"positive_quantiles":       "分位数数量必须为正数",
+	"no_valid_elements":        "没有可用于计算分位数的元素",
+	"duplicate_edges":          "分位数边界不唯一: %v",
+	"unknown_rank_method":      "未知排名方法 %v",
+	"unknown_na_option":        "未知 NaN 处理方式 %v",
+	"no_capture_groups":        "正则表达式中没有捕获组",
+	"unknown_pad_side":         "未知填充位置 %v",
+	"nil_elements":             "NewElements 不能为 nil",
+	"type_registered":          "类型 %v 是内置类型或已经注册",
+	"negative_scale":           "小数位数不能为负数: %d",
+	"scale_too_large":          "小数位数 %d 超过上限 %d",
+	"unknown_rounding":         "未知舍入方式 %v",
+	"division_by_zero":         "除数为零",
+	"int_range":                "第 %d 个值超出 %s 的范围",
+	"int_fraction":             "第 %d 个值有小数部分，无法转换为 %s",
+	"int_overflow":             "结果 %s 超出 %s 的范围",
+	"unsupported_value":        "第 %d 个值的类型无法转换为 %s",
+
+	// dataframe
+	"series_has_errors":     "第 %d 个 Series 存在错误",
+	"no_series":             "没有提供 Series",
+	"empty_dataframe":       "空 DataFrame",
+	"different_dimensions":  "参数的维度不同",
+	"column_count_mismatch": "列数不同",
+	"column_error":          "列 %s",
+	"nil_input":             "输入为nil",
+	"aggregation_length":    "typs 与 colnames 的长度不同",
+	"unknown_aggregation":   "未找到该方法：%v",
+	"unknown_filter_agg":    "未知的聚合类型 %d",
+	"group_key_type":        "未找到类型",
+	"incompatible_columns":  "列名不兼容",
+	"no_arguments":          "无参数",
+	"row_apply":             "在行 %d 上应用函数时发生错误",
+	"row_lengths":           "行具有不同的长度",
+	"load_nil":              "无法从 <nil> 值创建DataFrame",
+	"load_empty_slice":      "无法从空切片创建DataFrame",
+	"load_type":             "类型 %s (%s) 不受支持，必须是 []struct",
+	"struct_tag":            "字段 %s 上的结构体标签格式错误: %s",
+	"struct_target":         "目标必须是指向结构体切片的指针，而不是 %v",
+	"too_many_names":        "列名过多",
+	"too_few_names":         "列名不足",
+	"empty_array":           "空数组",
+	"no_join_keys":          "未指定连接键",
+	"left_key_not_found":    "在左侧 DataFrame 中找不到键 %q",
+	"right_key_not_found":   "在右侧 DataFrame 中找不到键 %q",
+	"type_detection":        "无法检测到类型",
+	"positive_ntile":        "桶数必须为正数",
+	"positive_chunk_size":   "块大小必须为正数",
+	"streaming_median":      "按块聚合不支持中位数",
+	"ragged_row":            "第 %d 行有 %d 个字段，应为 %d 个",
+	"unknown_encoding":      "未知字符编码 %q",
+	"write_compression":     "不支持写入压缩格式 %q",
+	"json_line":             "第 %d 行不是有效的 JSON 对象",
+	"json_trailing_data":    "JSON 对象之后有多余的数据",
+	"json_not_object":       "不是 JSON 对象",
+	"meta_column_conflict":  "附加字段 %q 与记录的列同名，请使用 MetaPrefix 设置前缀",
+	"column_lengths":        "列具有不同的长度",
+	"unknown_orient":        "未知 JSON 结构 %q",
+
+	// Describe 标签
+	"describe_column": "列名",
+	"describe_mean":   "平均值",
+	"describe_median": "中位数",
+	"describe_std":    "标准差",
+	"describe_min":    "最小值",
+	"describe_max":    "最大值",
+}
+
+// enMessages 是英文消息。
+var enMessages = map[string]string{
+	// 错误类别
+	"column_not_found":   "column not found",
+	"dimension_mismatch": "dimension mismatch",
+	"unknown_type":       "unsupported type",
+	"index_out_of_range": "index out of range",
+	"invalid_index":      "invalid index",
+	"unknown_comparator": "unknown comparator",
+	"conversion":         "conversion failed",
+	"empty":              "empty input",
+	"invalid_argument":   "invalid argument",
+
+	// series
+	"unknown_locale":           "unknown locale %q",
+	"column_not_found_name":    "column %q not found",
+	"unsupported_type":         "unsupported type %v",
+	"unknown_type_name":        "unknown type %v",
+	"argument_has_errors":      "argument has errors",
+	"input_has_errors":         "input has errors",
+	"index_dimension_mismatch": "index dimension mismatch",
+	"index_has_nan":            "index contains NaN",
+	"unknown_index_mode":       "unknown index mode",
+	"unknown_comparator_name":  "unknown comparator %v",
+	"compare_length_mismatch":  "length mismatch",
+	"comp_func_type":           "comparando is not a func(el Element) bool comparison function",
+	"slice_out_of_bounds":      "slice index out of bounds",
+	"convert_nan":              "can't convert NaN to %s",
+	"convert_inf":              "can't convert Inf to %s",
+	"convert_value":            "can't convert %s %q to %s",
+	"min_edges":                "at least two bin edges are required",
+	"edges_increasing":         "bin edges must be strictly increasing",
+	"edges_nan":                "bin edges must not be NaN",
+	"labels_count":             "number of labels must be one less than the number of edges",
+	"positive_quantiles":       "number of quantiles must be positive",
+	"no_valid_elements":        "no elements available to compute quantiles",
+	"duplicate_edges":          "quantile edges are not unique: %v",
+	"unknown_rank_method":      "unknown rank method %v",
+	"unknown_na_option":        "unknown NaN option %v",
+	"no_capture_groups":        "regular expression has no capture groups",
+	"unknown_pad_side":         "unknown pad side %v",
+	"nil_elements":             "NewElements must not be nil",
+	"type_registered":          "type %v is a built-in type or already registered",
+	"negative_scale":           "scale must not be negative: %d",
+	"scale_too_large":          "scale %d exceeds the limit %d",
+	"unknown_rounding":         "unknown rounding mode %v",
+	"division_by_zero":         "division by zero",
+	"int_range":                "value %d is out of range for %s",
+	"int_fraction":             "value %d has a fractional part and cannot be converted to %s",
+	"int_overflow":             "result %s is out of range for %s",
+	"unsupported_value":        "value %d has a type that cannot be converted to %s",
+
+	// dataframe
+	"series_has_errors":     "error on series %d",
+	"no_series":             "no Series given",
+	"empty_dataframe":       "empty DataFrame",
+	"different_dimensions":  "arguments have different dimensions",
+	"column_count_mismatch": "different number of columns",
+	"column_error":          "column %s",
+	"nil_input":             "input is nil",
+	"aggregation_length":    "len(typs) != len(colnames)",
+	"unknown_aggregation":   "unknown aggregation: %v",
+	"unknown_filter_agg":    "unknown aggregation %d",
+	"group_key_type":        "unsupported group key type",
+	"incompatible_columns":  "incompatible column names",
+	"no_arguments":          "no arguments",
+	"row_apply":             "error applying function on row %d",
+	"row_lengths":           "rows have different lengths",
+	"load_nil":              "can't create DataFrame from <nil> value",
+	"load_empty_slice":      "can't create DataFrame from empty slice",
+	"load_type":             "type %s (%s) not supported, must be []struct",
+	"struct_tag":            "malformed struct tag on field %s: %s",
+	"struct_target":         "target must be a pointer to a slice of structs, not %v",
+	"too_many_names":        "too many column names",
+	"too_few_names":         "not enough column names",
+	"empty_array":           "empty array",
+	"no_join_keys":          "join keys not specified",
+	"left_key_not_found":    "can't find key %q on left DataFrame",
+	"right_key_not_found":   "can't find key %q on right DataFrame",
+	"type_detection":        "couldn't detect type",
+	"positive_ntile":        "number of buckets must be positive",
+	"positive_chunk_size":   "chunk size must be positive",
+	"streaming_median":      "median is not supported when aggregating chunks",
+	"ragged_row":            "row %d has %d fields, expected %d",
+	"unknown_encoding":      "unknown encoding %q",
+	"write_compression":     "unsupported output compression %q",
+	"json_line":             "line %d is not a valid JSON object",
+	"json_trailing_data":    "unexpected data after JSON object",
+	"json_not_object":       "not a JSON object",
+	"meta_column_conflict":  "meta field %q conflicts with a record column, use MetaPrefix to set a prefix",
+	"column_lengths":        "columns have different lengths",
+	"unknown_orient":        "unknown JSON orient %q",
+
+	// Describe 标签
+	"describe_column": "column",
+	"describe_mean":   "mean",
+	"describe_median": "median",
+	"describe_std":    "std",
+	"describe_min":    "min",
+	"describe_max":    "max",
+}
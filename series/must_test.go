@@ -0,0 +1,95 @@
+package series
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoPanics(t *testing.T) {
+	s := New([]int{1, 2, 3}, Int, "x")
+	tests := []struct {
+		name string
+		got  Series
+		kind ErrorKind
+	}{
+		{"comp func type", s.Compare(CompFunc, 1), ErrInvalidArgument},
+		{"unknown comparator", s.Compare("~", 1), ErrUnknownComparator},
+		{"compare length", s.Compare(Eq, []int{1, 2}), ErrDimensionMismatch},
+		{"subset out of range", s.Subset([]int{5}), ErrIndexOutOfRange},
+		{"subset mask length", s.Subset([]bool{true}), ErrDimensionMismatch},
+		{"set length", s.Copy().Set([]int{0, 1}, New([]int{1}, Int, "")), ErrDimensionMismatch},
+		{"slice", s.Slice(2, 1), ErrIndexOutOfRange},
+		{"unknown type", New([]int{1}, "complex", "x"), ErrUnknownType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.got.Err, tt.kind) {
+				t.Errorf("err = %v, want %v", tt.got.Err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestElemOutOfRange(t *testing.T) {
+	s := New([]int{1, 2}, Int, "x")
+	for _, i := range []int{-1, 2, 99} {
+		e := s.Elem(i)
+		if !e.IsNA() || e.Type() != Int {
+			t.Errorf("Elem(%d) = %v (%v), want Int NaN", i, e, e.Type())
+		}
+		if v := s.Val(i); v != nil {
+			t.Errorf("Val(%d) = %v, want nil", i, v)
+		}
+	}
+	if v := s.Val(1); v != 2 {
+		t.Errorf("Val(1) = %v, want 2", v)
+	}
+}
+
+func TestElemE(t *testing.T) {
+	s := New([]int{1, 2}, Int, "x")
+	for _, i := range []int{-1, 2, 99} {
+		if e, err := s.ElemE(i); e != nil || !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("ElemE(%d) = %v, %v, want nil, %v", i, e, err, ErrIndexOutOfRange)
+		}
+		if v, err := s.ValE(i); v != nil || !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("ValE(%d) = %v, %v, want nil, %v", i, v, err, ErrIndexOutOfRange)
+		}
+	}
+	if e, err := s.ElemE(0); err != nil || e.String() != "1" {
+		t.Errorf("ElemE(0) = %v, %v, want 1, nil", e, err)
+	}
+	if v, err := s.ValE(1); err != nil || v != 2 {
+		t.Errorf("ValE(1) = %v, %v, want 2, nil", v, err)
+	}
+	na := New([]interface{}{nil}, Int, "x")
+	if v, err := na.ValE(0); err != nil || v != nil {
+		t.Errorf("ValE(0) of NaN = %v, %v, want nil, nil", v, err)
+	}
+}
+
+func TestMust(t *testing.T) {
+	s := New([]int{1, 2}, Int, "x")
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"must elem", func() { s.MustElem(2) }},
+		{"must val", func() { s.MustVal(-1) }},
+		{"must", func() { Must(s.Subset([]int{9})) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || !errors.Is(err, ErrIndexOutOfRange) {
+					t.Errorf("recovered %v, want ErrIndexOutOfRange", r)
+				}
+			}()
+			tt.f()
+		})
+	}
+	if got := Must(s); got.Len() != 2 {
+		t.Errorf("Must returned %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package series
+
+import "math"
+
+// RankMethod 表示排名时处理相同值的方法。
+type RankMethod string
+
+// 支持的排名方法
+const (
+	RankAverage RankMethod = "average" // 相同值取排名的平均值
+	RankMin     RankMethod = "min"     // 相同值取最小排名
+	RankMax     RankMethod = "max"     // 相同值取最大排名
+	RankFirst   RankMethod = "first"   // 相同值按出现顺序排名
+	RankDense   RankMethod = "dense"   // 与 min 相同，但排名之间不留空缺
+)
+
+// NAOption 表示排名时处理 NaN 元素的方式。
+type NAOption string
+
+// 支持的 NaN 处理方式
+const (
+	NAKeep   NAOption = "keep"   // NaN 元素的排名为 NaN
+	NATop    NAOption = "top"    // NaN 元素排在最前
+	NABottom NAOption = "bottom" // NaN 元素排在最后
+)
+
+// Rank 方法返回 Series 中每个元素的排名，结果为 Float 类型的 Series，排名从 1 开始。
+// method 决定相同值的处理方式，ascending 为 false 时按降序排名，naOption 决定 NaN 元素的处理方式，
+// pct 为 true 时返回排名占排名总数的比例。
+func (s Series) Rank(method RankMethod, ascending bool, naOption NAOption, pct bool) Series {
+	if s.Err != nil {
+		return s
+	}
+	switch method {
+	case RankAverage, RankMin, RankMax, RankFirst, RankDense:
+	default:
+		empty := New([]float64{}, Float, s.Name)
+		empty.Err = NewError(ErrInvalidArgument, "rank", "unknown_rank_method", method)
+		return empty
+	}
+	switch naOption {
+	case NAKeep, NATop, NABottom:
+	default:
+		empty := New([]float64{}, Float, s.Name)
+		empty.Err = NewError(ErrInvalidArgument, "rank", "unknown_na_option", naOption)
+		return empty
+	}
+
+	// 将排序后的元素划分为值相同的组
+	var groups [][]int
+	var nas []int
+	for _, i := range s.Order(!ascending) {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			nas = append(nas, i)
+			continue
+		}
+		if n := len(groups); n > 0 && e.Eq(s.elements.Elem(groups[n-1][0])) {
+			groups[n-1] = append(groups[n-1], i)
+			continue
+		}
+		groups = append(groups, []int{i})
+	}
+	if len(nas) > 0 {
+		switch naOption {
+		case NATop:
+			groups = append([][]int{nas}, groups...)
+		case NABottom:
+			groups = append(groups, nas)
+		}
+	}
+
+	ranks := make([]float64, s.Len())
+	for i := range ranks {
+		ranks[i] = math.NaN()
+	}
+	pos := 0
+	for dense, group := range groups {
+		k := len(group)
+		for j, i := range group {
+			switch method {
+			case RankAverage:
+				ranks[i] = float64(pos) + float64(k+1)/2
+			case RankMin:
+				ranks[i] = float64(pos + 1)
+			case RankMax:
+				ranks[i] = float64(pos + k)
+			case RankFirst:
+				ranks[i] = float64(pos + j + 1)
+			case RankDense:
+				ranks[i] = float64(dense + 1)
+			}
+		}
+		pos += k
+	}
+
+	if pct {
+		total := float64(pos)
+		if method == RankDense {
+			total = float64(len(groups))
+		}
+		for i := range ranks {
+			ranks[i] /= total
+		}
+	}
+	return New(ranks, Float, s.Name)
+}
@@ -0,0 +1,53 @@
+package series
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	s := New([]interface{}{3, 1, nil, 3, 2, 3}, Int, "x")
+	tests := []struct {
+		name      string
+		method    RankMethod
+		ascending bool
+		na        NAOption
+		pct       bool
+		want      []string
+	}{
+		{"average", RankAverage, true, NAKeep, false, []string{"4.000000", "1.000000", "NaN", "4.000000", "2.000000", "4.000000"}},
+		{"min", RankMin, true, NAKeep, false, []string{"3.000000", "1.000000", "NaN", "3.000000", "2.000000", "3.000000"}},
+		{"max", RankMax, true, NAKeep, false, []string{"5.000000", "1.000000", "NaN", "5.000000", "2.000000", "5.000000"}},
+		{"first", RankFirst, true, NAKeep, false, []string{"3.000000", "1.000000", "NaN", "4.000000", "2.000000", "5.000000"}},
+		{"dense", RankDense, true, NAKeep, false, []string{"3.000000", "1.000000", "NaN", "3.000000", "2.000000", "3.000000"}},
+		{"descending", RankMin, false, NAKeep, false, []string{"1.000000", "5.000000", "NaN", "1.000000", "4.000000", "1.000000"}},
+		{"descending first", RankFirst, false, NAKeep, false, []string{"1.000000", "5.000000", "NaN", "2.000000", "4.000000", "3.000000"}},
+		{"na top", RankMin, true, NATop, false, []string{"4.000000", "2.000000", "1.000000", "4.000000", "3.000000", "4.000000"}},
+		{"na bottom", RankMin, true, NABottom, false, []string{"3.000000", "1.000000", "6.000000", "3.000000", "2.000000", "3.000000"}},
+		{"pct", RankMax, true, NAKeep, true, []string{"1.000000", "0.200000", "NaN", "1.000000", "0.400000", "1.000000"}},
+		{"dense pct", RankDense, true, NAKeep, true, []string{"1.000000", "0.333333", "NaN", "1.000000", "0.666667", "1.000000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, s.Rank(tt.method, tt.ascending, tt.na, tt.pct), Float, tt.want)
+		})
+	}
+}
+
+func TestRankInvalid(t *testing.T) {
+	s := New([]interface{}{1, nil}, Int, "x")
+	if got := s.Rank("median", true, NAKeep, false); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("method: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	if got := s.Rank(RankMin, true, "skip", false); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("na option: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	// 没有 NaN 元素时同样检查参数
+	noNA := New([]float64{1, 2}, Float, "x")
+	if got := noNA.Rank(RankAverage, true, "bogus", false); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("na option without NaN: err = %v, want ErrInvalidArgument", got.Err)
+	}
+	if got := New([]float64{}, Float, "x").Rank("median", true, NAKeep, false); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("method on empty series: err = %v, want ErrInvalidArgument", got.Err)
+	}
+}
@@ -0,0 +1,158 @@
+package series
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// TypeInfo 描述通过 RegisterType 注册的 Series 类型。
+type TypeInfo struct {
+	// NewElements 创建长度为 n 的元素数组，元素之后通过 Element.Set 设置。
+	// 元素的 Set 方法需要接受 nil（表示 NaN）、同类型的 Element 以及 Parse 返回的值。
+	NewElements func(n int) Elements
+
+	// Parse 将字符串解析为传给 Element.Set 的值，用于从字符串创建 Series，例如 ReadCSV 和 ReadJSON。
+	// 字符串 "NaN" 不会传给 Parse，而是设置为 NaN。为 nil 时直接将字符串传给 Element.Set。
+	Parse func(string) (interface{}, error)
+
+	// Compare 比较两个非 NaN 元素，a 小于、等于、大于 b 时分别返回负数、0、正数，
+	// 用于 Compare 和 Order。为 nil 时使用元素自身的比较方法。
+	Compare func(a, b Element) int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Type]TypeInfo{}
+)
+
+// RegisterType 注册自定义的 Series 类型 t。注册后可以像内置类型一样使用 t 创建 Series，
+// Subset、Copy、Append、Compare、Order 等方法以及 dataframe 包的加载选项都支持该类型。
+// t 不能是内置类型或已经注册的类型，info.NewElements 不能为 nil。
+func RegisterType(t Type, info TypeInfo) error {
+	if info.NewElements == nil {
+		return NewError(ErrInvalidArgument, "RegisterType", "nil_elements")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[t]; ok || isBuiltinType(t) {
+		return NewError(ErrInvalidArgument, "RegisterType", "type_registered", t)
+	}
+	registry[t] = info
+	return nil
+}
+
+// LookupType 返回通过 RegisterType 注册的类型 t 的信息，内置类型和未注册的类型返回 false。
+func LookupType(t Type) (TypeInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	info, ok := registry[t]
+	return info, ok
+}
+
+// RegisteredTypes 返回所有通过 RegisterType 注册的类型，按名称排序。
+func RegisteredTypes() []Type {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	types := make([]Type, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// isBuiltinType 检查 t 是否为内置类型。
+func isBuiltinType(t Type) bool {
+	switch t {
+	case String, Int, Float, Bool, Categorical:
+		return true
+	}
+	_, sized := intKinds[t]
+	return sized || IsDecimal(t)
+}
+
+// interfaceValues 将传给 New 的值转换为逐个设置的值：nil 为一个 NaN 值，Series 为其元素，
+// 切片为其中的每个值，其他值为单个值。
+func interfaceValues(values interface{}) []interface{} {
+	switch v := values.(type) {
+	case nil:
+		return []interface{}{nil}
+	case Series:
+		ret := make([]interface{}, v.Len())
+		for i := range ret {
+			ret[i] = v.elements.Elem(i)
+		}
+		return ret
+	}
+	if v := reflect.ValueOf(values); v.Kind() == reflect.Slice {
+		ret := make([]interface{}, v.Len())
+		for i := range ret {
+			ret[i] = v.Index(i).Interface()
+		}
+		return ret
+	}
+	return []interface{}{values}
+}
+
+// copyElements 使用注册的类型信息创建包含 src 中 idx 对应元素的新元素数组。
+func (info TypeInfo) copyElements(src Elements, idx []int) Elements {
+	dst := info.NewElements(len(idx))
+	for k, i := range idx {
+		dst.Elem(k).Set(src.Elem(i))
+	}
+	return dst
+}
+
+// setElements 使用注册的类型信息创建元素数组，并依次将 values 设置为元素的值。
+// values 为 []string 且类型提供了 Parse 时先解析字符串。
+func (info TypeInfo) setElements(t Type, values []interface{}) (Elements, error) {
+	elements := info.NewElements(len(values))
+	for i, v := range values {
+		if str, ok := v.(string); ok && info.Parse != nil {
+			if str == "NaN" {
+				v = nil
+			} else {
+				parsed, err := info.Parse(str)
+				if err != nil {
+					return nil, NewError(ErrConversion, "", "convert_value", String, str, t).Wrap(err)
+				}
+				v = parsed
+			}
+		}
+		elements.Elem(i).Set(v)
+	}
+	return elements, nil
+}
+
+// compareRegistered 使用注册类型的比较函数比较 a 和 b，与内置类型一样，任一元素为 NaN 时结果为 false。
+func compareRegistered(cmp func(a, b Element) int, a, b Element, c Comparator) bool {
+	if a.IsNA() || b.IsNA() {
+		return false
+	}
+	r := cmp(a, b)
+	switch c {
+	case Eq:
+		return r == 0
+	case Neq:
+		return r != 0
+	case Greater:
+		return r > 0
+	case GreaterEq:
+		return r >= 0
+	case Less:
+		return r < 0
+	}
+	return r <= 0
+}
+
+// comparedElements 使用注册类型的比较函数排序 indexedElements。
+type comparedElements struct {
+	indexedElements
+	cmp func(a, b Element) int
+}
+
+// Less 方法使用比较函数比较两个元素。
+func (e comparedElements) Less(i, j int) bool {
+	return e.cmp(e.indexedElements[i].element, e.indexedElements[j].element) < 0
+}
@@ -0,0 +1,170 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Version 是测试用的自定义类型，按 "主版本.次版本" 的数值而不是字符串比较。
+const Version Type = "version"
+
+type versionElements []versionElement
+
+func (e versionElements) Len() int           { return len(e) }
+func (e versionElements) Elem(i int) Element { return &e[i] }
+
+type versionElement struct {
+	major, minor int
+	nan          bool
+}
+
+func (e *versionElement) Set(value interface{}) {
+	switch v := value.(type) {
+	case [2]int:
+		e.major, e.minor, e.nan = v[0], v[1], false
+	case *versionElement:
+		*e = *v
+	case string:
+		p, err := parseVersion(v)
+		if err != nil {
+			e.nan = true
+			return
+		}
+		e.Set(p)
+	default:
+		e.nan = true
+	}
+}
+
+func (e versionElement) Eq(x Element) bool        { return e.String() == x.String() }
+func (e versionElement) Neq(x Element) bool       { return !e.Eq(x) }
+func (e versionElement) Less(x Element) bool      { return e.String() < x.String() }
+func (e versionElement) LessEq(x Element) bool    { return e.String() <= x.String() }
+func (e versionElement) Greater(x Element) bool   { return e.String() > x.String() }
+func (e versionElement) GreaterEq(x Element) bool { return e.String() >= x.String() }
+func (e versionElement) Copy() Element            { c := e; return &c }
+func (e versionElement) Val() ElementValue        { return e.String() }
+func (e versionElement) Int() (int, error)        { return e.major, nil }
+func (e versionElement) Float() float64           { return float64(e.major) }
+func (e versionElement) Bool() (bool, error)      { return false, errors.New("not a bool") }
+func (e versionElement) IsNA() bool               { return e.nan }
+func (e versionElement) Type() Type               { return Version }
+
+func (e versionElement) String() string {
+	if e.nan {
+		return "NaN"
+	}
+	return fmt.Sprintf("%d.%d", e.major, e.minor)
+}
+
+func parseVersion(s string) (interface{}, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("bad version")
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return [2]int{major, minor}, nil
+}
+
+func compareVersions(a, b Element) int {
+	x, y := a.(*versionElement), b.(*versionElement)
+	if x.major != y.major {
+		return x.major - y.major
+	}
+	return x.minor - y.minor
+}
+
+var (
+	versionOnce sync.Once
+	versionErr  error
+)
+
+// registerVersion 注册 Version 类型，多次调用只注册一次。
+func registerVersion() error {
+	versionOnce.Do(func() {
+		versionErr = RegisterType(Version, TypeInfo{
+			NewElements: func(n int) Elements { return make(versionElements, n) },
+			Parse:       parseVersion,
+			Compare:     compareVersions,
+		})
+	})
+	return versionErr
+}
+
+func TestRegisterType(t *testing.T) {
+	if err := registerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := LookupType(Version); !ok {
+		t.Error("LookupType: version not found")
+	}
+	found := false
+	for _, typ := range RegisteredTypes() {
+		found = found || typ == Version
+	}
+	if !found {
+		t.Errorf("RegisteredTypes = %v, missing %v", RegisteredTypes(), Version)
+	}
+
+	tests := []struct {
+		name string
+		t    Type
+		info TypeInfo
+	}{
+		{"duplicate", Version, TypeInfo{NewElements: func(n int) Elements { return make(versionElements, n) }}},
+		{"builtin", Int, TypeInfo{NewElements: func(n int) Elements { return make(versionElements, n) }}},
+		{"sized builtin", Int8, TypeInfo{NewElements: func(n int) Elements { return make(versionElements, n) }}},
+		{"nil elements", "other", TypeInfo{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterType(tt.t, tt.info); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+	if _, ok := LookupType(Int); ok {
+		t.Error("LookupType(Int) = true, want false")
+	}
+}
+
+func TestRegisteredTypeSeries(t *testing.T) {
+	if err := registerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	s := New([]string{"1.10", "1.9", "NaN", "0.1"}, Version, "v")
+	checkSeries(t, s, Version, []string{"1.10", "1.9", "NaN", "0.1"})
+
+	checkSeries(t, s.Subset([]int{3, 0}), Version, []string{"0.1", "1.10"})
+	checkSeries(t, s.Copy(), Version, []string{"1.10", "1.9", "NaN", "0.1"})
+	checkSeries(t, s.Concat(New([]string{"2.0"}, Version, "")), Version, []string{"1.10", "1.9", "NaN", "0.1", "2.0"})
+	checkSeries(t, s.Empty(), Version, []string{})
+
+	appended := s.Copy()
+	appended.Append([]string{"3.1"})
+	checkSeries(t, appended, Version, []string{"1.10", "1.9", "NaN", "0.1", "3.1"})
+
+	// 使用注册的比较函数：1.9 < 1.10，NaN 排在最后
+	if got, want := s.Order(false), []int{3, 1, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Order = %v, want %v", got, want)
+	}
+	if got, want := s.Compare(Greater, "1.9").Records(), []string{"true", "false", "false", "false"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare = %v, want %v", got, want)
+	}
+
+	if bad := New([]string{"x"}, Version, ""); !errors.Is(bad.Err, ErrConversion) {
+		t.Errorf("parse error = %v, want %v", bad.Err, ErrConversion)
+	}
+}
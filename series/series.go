@@ -105,10 +105,22 @@ type Type string
 
 // 支持的 Series 类型
 const (
-	String Type = "string"
-	Int    Type = "int"
-	Float  Type = "float"
-	Bool   Type = "bool"
+	String      Type = "string"
+	Int         Type = "int"
+	Float       Type = "float"
+	Bool        Type = "bool"
+	Categorical Type = "categorical"
+	Decimal     Type = "decimal"
+
+	// 固定位数的整数类型，设置超出范围的值时报告错误
+	Int8   Type = "int8"
+	Int16  Type = "int16"
+	Int32  Type = "int32"
+	Int64  Type = "int64"
+	Uint8  Type = "uint8"
+	Uint16 Type = "uint16"
+	Uint32 Type = "uint32"
+	Uint64 Type = "uint64"
 )
 
 // Indexes 表示可用于选择 Series 子集元素的元素。目前支持以下类型：
@@ -126,6 +138,26 @@ func New(values interface{}, t Type, name string) Series {
 		Name: name,
 		t:    t,
 	}
+	// DecimalOf 返回的类型创建使用该规格的 Decimal Series
+	spec, isDecimal := decimalSpecOf(t)
+	if isDecimal {
+		t, ret.t = Decimal, Decimal
+	}
+	if info, ok := LookupType(t); ok {
+		elements, err := info.setElements(t, interfaceValues(values))
+		if err != nil {
+			ret.elements = info.NewElements(0)
+			ret.Err = WrapError("New", err)
+			return ret
+		}
+		ret.elements = elements
+		return ret
+	}
+	if !isBuiltinType(t) {
+		ret.elements = make(stringElements, 0)
+		ret.Err = NewError(ErrUnknownType, "New", "unknown_type_name", t)
+		return ret
+	}
 
 	// 预先分配元素
 	preAlloc := func(n int) {
@@ -138,8 +170,12 @@ func New(values interface{}, t Type, name string) Series {
 			ret.elements = make(floatElements, n)
 		case Bool:
 			ret.elements = make(boolElements, n)
+		case Categorical:
+			ret.elements = newCategoricalElements(n, &categoryDict{newCategories(nil, false)})
+		case Decimal:
+			ret.elements = newDecimalElements(n, spec)
 		default:
-			panic(fmt.Sprintf("unknown type %v", t))
+			ret.elements = newSizedIntElements(n, intKinds[t])
 		}
 	}
 
@@ -198,6 +234,17 @@ func New(values interface{}, t Type, name string) Series {
 		}
 	}
 
+	// 固定位数的整数类型报告超出范围和有小数部分的值，Decimal 类型报告不支持的值
+	switch e := ret.elements.(type) {
+	case sizedIntElements:
+		if err := e.conversionError("New"); err != nil {
+			ret.Err = err
+		}
+	case decimalElements:
+		if err := e.conversionError("New"); err != nil {
+			ret.Err = err
+		}
+	}
 	return ret
 }
 
@@ -221,8 +268,23 @@ func Bools(values interface{}) Series {
 	return New(values, Bool, "")
 }
 
-// Empty 返回与相同类型的空 Series。
+// Empty 返回与相同类型的空 Series。Categorical 类型的 Series 与原 Series 共享类别字典，
+// 向其中添加类别时复制字典，不影响原 Series。
 func (s Series) Empty() Series {
+	if e, ok := s.elements.(categoricalElements); ok {
+		return Series{
+			Name:     s.Name,
+			elements: newCategoricalElements(0, e.dict.share()),
+			t:        s.t,
+		}
+	}
+	if e, ok := s.elements.(decimalElements); ok {
+		return Series{
+			Name:     s.Name,
+			elements: newDecimalElements(0, e.spec),
+			t:        s.t,
+		}
+	}
 	return New([]int{}, s.t, s.Name)
 }
 
@@ -246,6 +308,51 @@ func (s *Series) Append(values interface{}) {
 		s.elements = append(s.elements.(floatElements), news.elements.(floatElements)...)
 	case Bool:
 		s.elements = append(s.elements.(boolElements), news.elements.(boolElements)...)
+	case Categorical:
+		// 使用原 Series 的类别字典重新编码新元素
+		elements := s.elements.(categoricalElements)
+		for i := 0; i < news.Len(); i++ {
+			el := categoricalElement{dict: elements.dict}
+			el.Set(news.elements.Elem(i))
+			elements.elements = append(elements.elements, el)
+		}
+		s.elements = elements
+	case Decimal:
+		if news.Err != nil {
+			s.Err = news.Err
+			return
+		}
+		// 使用原 Series 的规格设置新元素
+		elements := s.elements.(decimalElements)
+		for i := 0; i < news.Len(); i++ {
+			el := decimalElement{spec: elements.spec}
+			el.Set(news.elements.Elem(i))
+			elements.elements = append(elements.elements, el)
+		}
+		s.elements = elements
+	case Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32, Uint64:
+		if news.Err != nil {
+			s.Err = news.Err
+			return
+		}
+		s.elements = append(s.elements.(sizedIntElements), news.elements.(sizedIntElements)...)
+	default:
+		if news.Err != nil {
+			s.Err = news.Err
+			return
+		}
+		info, ok := LookupType(s.t)
+		if !ok {
+			return
+		}
+		elements := info.NewElements(s.Len() + news.Len())
+		for i := 0; i < s.Len(); i++ {
+			elements.Elem(i).Set(s.elements.Elem(i))
+		}
+		for i := 0; i < news.Len(); i++ {
+			elements.Elem(s.Len() + i).Set(news.elements.Elem(i))
+		}
+		s.elements = elements
 	}
 }
 
@@ -255,7 +362,7 @@ func (s Series) Concat(x Series) Series {
 		return s
 	}
 	if err := x.Err; err != nil {
-		s.Err = fmt.Errorf("concat error: argument has errors: %v", err)
+		s.Err = NewError(ErrInvalidArgument, "concat", "argument_has_errors").Wrap(err)
 		return s
 	}
 	y := s.Copy()
@@ -273,6 +380,12 @@ func (s Series) Subset(indexes Indexes) Series {
 		s.Err = err
 		return s
 	}
+	for _, i := range idx {
+		if i < 0 || i >= s.Len() {
+			s.Err = NewError(ErrIndexOutOfRange, "subset", "")
+			return s
+		}
+	}
 	ret := Series{
 		Name: s.Name,
 		t:    s.t,
@@ -302,8 +415,28 @@ func (s Series) Subset(indexes Indexes) Series {
 			elements[k] = s.elements.(boolElements)[i]
 		}
 		ret.elements = elements
+	case Categorical:
+		ret.elements = s.elements.(categoricalElements).subset(idx)
+	case Decimal:
+		src := s.elements.(decimalElements)
+		elements := newDecimalElements(len(idx), src.spec)
+		for k, i := range idx {
+			elements.elements[k] = src.elements[i]
+		}
+		ret.elements = elements
+	case Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32, Uint64:
+		elements := make(sizedIntElements, len(idx))
+		for k, i := range idx {
+			elements[k] = s.elements.(sizedIntElements)[i]
+		}
+		ret.elements = elements
 	default:
-		panic("unknown series type")
+		info, ok := LookupType(s.t)
+		if !ok {
+			s.Err = NewError(ErrUnknownType, "subset", "unsupported_type", s.t)
+			return s
+		}
+		ret.elements = info.copyElements(s.elements, idx)
 	}
 	return ret
 }
@@ -314,7 +447,7 @@ func (s Series) Set(indexes Indexes, newValue Series) Series {
 		return s
 	}
 	if err := newValue.Err; err != nil {
-		s.Err = fmt.Errorf("set error: 参数存在错误: %v", err)
+		s.Err = NewError(ErrInvalidArgument, "set", "argument_has_errors").Wrap(err)
 		return s
 	}
 	idx, err := parseIndexes(s.Len(), indexes)
@@ -323,15 +456,22 @@ func (s Series) Set(indexes Indexes, newValue Series) Series {
 		return s
 	}
 	if len(idx) != newValue.Len() {
-		s.Err = fmt.Errorf("set error: 维度不匹配")
+		s.Err = NewError(ErrDimensionMismatch, "set", "")
 		return s
 	}
 	for k, i := range idx {
 		if i < 0 || i >= s.Len() {
-			s.Err = fmt.Errorf("set error: 索引超出范围")
+			s.Err = NewError(ErrIndexOutOfRange, "set", "")
 			return s
 		}
-		s.elements.Elem(i).Set(newValue.elements.Elem(k))
+		e := s.elements.Elem(i)
+		e.Set(newValue.elements.Elem(k))
+		if si, ok := e.(*sizedIntElement); ok {
+			if err := si.conversionError("set", k); err != nil {
+				s.Err = err
+				return s
+			}
+		}
 	}
 	return s
 }
@@ -360,6 +500,7 @@ func (s Series) Compare(comparator Comparator, comparando interface{}) Series {
 	if err := s.Err; err != nil {
 		return s
 	}
+	info, _ := LookupType(s.t)
 	compareElements := func(a, b Element, c Comparator) (bool, error) {
 		var ret bool
 		switch c {
@@ -376,7 +517,11 @@ func (s Series) Compare(comparator Comparator, comparando interface{}) Series {
 		case LessEq:
 			ret = a.LessEq(b)
 		default:
-			return false, fmt.Errorf("未知比较器: %v", c)
+			return false, NewError(ErrUnknownComparator, "compare", "unknown_comparator_name", c)
+		}
+		// 注册类型提供了比较函数时使用比较函数的结果
+		if info.Compare != nil {
+			ret = compareRegistered(info.Compare, a, b, c)
 		}
 		return ret, nil
 	}
@@ -387,7 +532,9 @@ func (s Series) Compare(comparator Comparator, comparando interface{}) Series {
 	if comparator == CompFunc {
 		f, ok := comparando.(compFunc)
 		if !ok {
-			panic("comparando 不是一个 func(el Element) bool 类型的比较函数")
+			ret := s.Empty()
+			ret.Err = NewError(ErrInvalidArgument, "compare", "comp_func_type")
+			return ret
 		}
 
 		for i := 0; i < s.Len(); i++ {
@@ -399,6 +546,11 @@ func (s Series) Compare(comparator Comparator, comparando interface{}) Series {
 	}
 
 	comp := New(comparando, s.t, "")
+	if comp.Err != nil {
+		ret := s.Empty()
+		ret.Err = NewError(ErrInvalidArgument, "compare", "argument_has_errors").Wrap(comp.Err)
+		return ret
+	}
 	// In 比较器比较
 	if comparator == In {
 		for i := 0; i < s.Len(); i++ {
@@ -440,7 +592,7 @@ func (s Series) Compare(comparator Comparator, comparando interface{}) Series {
 	// 多元素比较
 	if s.Len() != comp.Len() {
 		s := s.Empty()
-		s.Err = fmt.Errorf("无法比较: 长度不匹配")
+		s.Err = NewError(ErrDimensionMismatch, "compare", "compare_length_mismatch")
 		return s
 	}
 	for i := 0; i < s.Len(); i++ {
@@ -475,6 +627,28 @@ func (s Series) Copy() Series {
 	case Int:
 		elements = make(intElements, s.Len())
 		copy(elements.(intElements), s.elements.(intElements))
+	case Categorical:
+		idx := make([]int, s.Len())
+		for i := range idx {
+			idx[i] = i
+		}
+		elements = s.elements.(categoricalElements).subset(idx)
+	case Decimal:
+		src := s.elements.(decimalElements)
+		dst := newDecimalElements(s.Len(), src.spec)
+		copy(dst.elements, src.elements)
+		elements = dst
+	case Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32, Uint64:
+		elements = make(sizedIntElements, s.Len())
+		copy(elements.(sizedIntElements), s.elements.(sizedIntElements))
+	default:
+		if info, ok := LookupType(s.t); ok {
+			idx := make([]int, s.Len())
+			for i := range idx {
+				idx[i] = i
+			}
+			elements = info.copyElements(s.elements, idx)
+		}
 	}
 	ret := Series{
 		Name:     name,
@@ -540,6 +714,9 @@ func (s Series) Type() Type {
 
 // Len 方法返回给定 Series 的长度。
 func (s Series) Len() int {
+	if s.elements == nil {
+		return 0
+	}
 	return s.elements.Len()
 }
 
@@ -563,16 +740,77 @@ func (s Series) Str() string {
 	return strings.Join(ret, "\n")
 }
 
-// Val 方法返回给定索引处的 Series 的值。如果索引超出范围，则会引发 panic。
+// Val 方法返回给定索引处的 Series 的值，与 Elem(i).Val() 相同。如果索引超出范围，则与 NaN 元素一样返回 nil；
+// 需要检测无效索引时使用 ValE。
 func (s Series) Val(i int) interface{} {
-	return s.elements.Elem(i).Val()
+	return s.Elem(i).Val()
 }
 
-// Elem 方法返回给定索引处的 Series 的元素。如果索引超出范围，则会引发 panic。
+// Elem 方法返回给定索引处的 Series 的元素。如果索引超出范围，则返回一个与 Series 类型相同的 NaN 元素，
+// 不会设置 Err，因此无法与真正的 NaN 元素区分。需要检测无效索引时，使用返回错误的 ElemE 和 ValE，
+// 或者使用会引发 panic 的 MustElem 和 MustVal。
 func (s Series) Elem(i int) Element {
+	if i < 0 || i >= s.Len() {
+		return s.naElement()
+	}
 	return s.elements.Elem(i)
 }
 
+// ValE 方法与 Val 相同，但在索引超出范围时返回 ErrIndexOutOfRange 类别的错误。
+func (s Series) ValE(i int) (interface{}, error) {
+	e, err := s.ElemE(i)
+	if err != nil {
+		return nil, err
+	}
+	return e.Val(), nil
+}
+
+// ElemE 方法与 Elem 相同，但在索引超出范围时返回 nil 元素和 ErrIndexOutOfRange 类别的错误。
+func (s Series) ElemE(i int) (Element, error) {
+	if i < 0 || i >= s.Len() {
+		return nil, NewError(ErrIndexOutOfRange, "elem", "")
+	}
+	return s.elements.Elem(i), nil
+}
+
+// MustVal 方法与 Val 相同，但在索引超出范围时引发 panic。
+func (s Series) MustVal(i int) interface{} {
+	return s.MustElem(i).Val()
+}
+
+// MustElem 方法与 Elem 相同，但在索引超出范围时引发 panic。
+func (s Series) MustElem(i int) Element {
+	e, err := s.ElemE(i)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// checkedSeries 返回 s 以及它的 Err 字段，用于以 E 结尾、通过返回值报告错误的方法。
+func checkedSeries(s Series) (Series, error) {
+	return s, s.Err
+}
+
+// naElement 返回一个与 Series 类型相同的 NaN 元素，类型未知时返回 String 类型的 NaN 元素。
+func (s Series) naElement() Element {
+	na := New(nil, s.t, "")
+	if na.Err != nil {
+		na = New(nil, String, "")
+	}
+	return na.elements.Elem(0)
+}
+
+// Must 在 Series 带有错误时引发 panic，否则返回 Series 本身。用于希望在出错时立即中止的调用方，例如：
+//
+//	s := series.Must(series.New(values, series.Int, "x").Compare(series.Greater, 1))
+func Must(s Series) Series {
+	if s.Err != nil {
+		panic(s.Err)
+	}
+	return s
+}
+
 // parseIndexes 方法解析给定 Series 的索引，长度为 `l`。不进行越界检查。
 func parseIndexes(l int, indexes Indexes) ([]int, error) {
 	var idx []int
@@ -584,7 +822,7 @@ func parseIndexes(l int, indexes Indexes) ([]int, error) {
 	case []bool:
 		bools := idxs
 		if len(bools) != l {
-			return nil, fmt.Errorf("索引错误: 索引维度不匹配")
+			return nil, NewError(ErrDimensionMismatch, "index", "index_dimension_mismatch")
 		}
 		for i, b := range bools {
 			if b {
@@ -594,10 +832,10 @@ func parseIndexes(l int, indexes Indexes) ([]int, error) {
 	case Series:
 		s := idxs
 		if err := s.Err; err != nil {
-			return nil, fmt.Errorf("索引错误: 新值存在错误: %v", err)
+			return nil, NewError(ErrInvalidIndex, "index", "argument_has_errors").Wrap(err)
 		}
 		if s.HasNaN() {
-			return nil, fmt.Errorf("索引错误: 索引包含 NaN")
+			return nil, NewError(ErrInvalidIndex, "index", "index_has_nan")
 		}
 		switch s.t {
 		case Int:
@@ -605,20 +843,21 @@ func parseIndexes(l int, indexes Indexes) ([]int, error) {
 		case Bool:
 			bools, err := s.Bool()
 			if err != nil {
-				return nil, fmt.Errorf("索引错误: %v", err)
+				return nil, NewError(ErrInvalidIndex, "index", "").Wrap(err)
 			}
 			return parseIndexes(l, bools)
 		default:
-			return nil, fmt.Errorf("索引错误: 未知索引模式")
+			return nil, NewError(ErrInvalidIndex, "index", "unknown_index_mode")
 		}
 	default:
-		return nil, fmt.Errorf("索引错误: 未知索引模式")
+		return nil, NewError(ErrInvalidIndex, "index", "unknown_index_mode")
 	}
 	return idx, nil
 }
 
 // Order 方法返回排序 Series 所需的索引。NaN 元素按出现顺序推送到末尾。
-func (s Series) Order(reverse bool) []int {
+// 可以通过 collation 指定 String 和 Categorical 类型 Series 的排序规则。
+func (s Series) Order(reverse bool, collation ...Collation) []int {
 	var ie indexedElements
 	var nasIdx []int
 	for i := 0; i < s.Len(); i++ {
@@ -631,6 +870,11 @@ func (s Series) Order(reverse bool) []int {
 	}
 	var srt sort.Interface
 	srt = ie
+	if c, ok := s.collated(collation); ok {
+		srt = collatedElements{ie, c}
+	} else if info, ok := LookupType(s.t); ok && info.Compare != nil {
+		srt = comparedElements{ie, info.Compare}
+	}
 	if reverse {
 		srt = sort.Reverse(srt)
 	}
@@ -676,6 +920,7 @@ func (s Series) Mean() float64 {
 func (s Series) Median() float64 {
 	if s.elements.Len() == 0 ||
 		s.Type() == String ||
+		s.Type() == Categorical ||
 		s.Type() == Bool {
 		return math.NaN()
 	}
@@ -697,7 +942,7 @@ func (s Series) Median() float64 {
 
 // Max 方法返回 Series 中的最大元素值。
 func (s Series) Max() float64 {
-	if s.elements.Len() == 0 || s.Type() == String {
+	if s.elements.Len() == 0 || s.Type() == String || s.Type() == Categorical {
 		return math.NaN()
 	}
 
@@ -711,15 +956,22 @@ func (s Series) Max() float64 {
 	return max.Float()
 }
 
-// MaxStr 方法返回字符串类型 Series 中的最大元素值。
-func (s Series) MaxStr() string {
-	if s.elements.Len() == 0 || s.Type() != String {
+// MaxStr 方法返回字符串或分类类型 Series 中的最大元素值。可以通过 collation 指定比较规则。
+func (s Series) MaxStr(collation ...Collation) string {
+	if s.elements.Len() == 0 || (s.Type() != String && s.Type() != Categorical) {
 		return ""
 	}
 
+	c, collated := s.collated(collation)
 	max := s.elements.Elem(0)
 	for i := 1; i < s.elements.Len(); i++ {
 		elem := s.elements.Elem(i)
+		if collated && !elem.IsNA() && !max.IsNA() {
+			if c(elem.String(), max.String()) > 0 {
+				max = elem
+			}
+			continue
+		}
 		if elem.Greater(max) {
 			max = elem
 		}
@@ -729,7 +981,7 @@ func (s Series) MaxStr() string {
 
 // Min 方法返回 Series 中的最小元素值。
 func (s Series) Min() float64 {
-	if s.elements.Len() == 0 || s.Type() == String {
+	if s.elements.Len() == 0 || s.Type() == String || s.Type() == Categorical {
 		return math.NaN()
 	}
 
@@ -743,15 +995,22 @@ func (s Series) Min() float64 {
 	return min.Float()
 }
 
-// MinStr 方法返回字符串类型 Series 中的最小元素值。
-func (s Series) MinStr() string {
-	if s.elements.Len() == 0 || s.Type() != String {
+// MinStr 方法返回字符串或分类类型 Series 中的最小元素值。可以通过 collation 指定比较规则。
+func (s Series) MinStr(collation ...Collation) string {
+	if s.elements.Len() == 0 || (s.Type() != String && s.Type() != Categorical) {
 		return ""
 	}
 
+	c, collated := s.collated(collation)
 	min := s.elements.Elem(0)
 	for i := 1; i < s.elements.Len(); i++ {
 		elem := s.elements.Elem(i)
+		if collated && !elem.IsNA() && !min.IsNA() {
+			if c(elem.String(), min.String()) < 0 {
+				min = elem
+			}
+			continue
+		}
 		if elem.Less(min) {
 			min = elem
 		}
@@ -762,7 +1021,7 @@ func (s Series) MinStr() string {
 // Quantile 方法返回 Series 样本，使得 x 大于或等于样本比例 p。
 // 注意: 当以字符串类型调用时，gonum/stat 会引发 panic。
 func (s Series) Quantile(p float64) float64 {
-	if s.Type() == String || s.Len() == 0 {
+	if s.Type() == String || s.Type() == Categorical || s.Len() == 0 {
 		return math.NaN()
 	}
 
@@ -786,7 +1045,7 @@ func (s Series) Map(f MapFunction) Series {
 
 // Sum 方法计算 Series 的和。
 func (s Series) Sum() float64 {
-	if s.elements.Len() == 0 || s.Type() == String || s.Type() == Bool {
+	if s.elements.Len() == 0 || s.Type() == String || s.Type() == Categorical || s.Type() == Bool {
 		return math.NaN()
 	}
 	sFloat := s.Float()
@@ -806,7 +1065,7 @@ func (s Series) Slice(j, k int) Series {
 
 	if j > k || j < 0 || k >= s.Len() {
 		empty := s.Empty()
-		empty.Err = fmt.Errorf("slice index out of bounds")
+		empty.Err = NewError(ErrIndexOutOfRange, "slice", "slice_out_of_bounds")
 		return empty
 	}
 
@@ -0,0 +1,20 @@
+package series
+
+import (
+	"reflect"
+	"testing"
+)
+
+// checkSeries 检查 Series 没有错误，并且类型和各元素的字符串表示与期望一致。
+func checkSeries(t *testing.T, got Series, typ Type, want []string) {
+	t.Helper()
+	if got.Err != nil {
+		t.Fatalf("unexpected error: %v", got.Err)
+	}
+	if got.Type() != typ {
+		t.Errorf("type = %v, want %v", got.Type(), typ)
+	}
+	if records := got.Records(); !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
@@ -0,0 +1,239 @@
+package series
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringAccessor 提供对 String 和 Categorical 类型 Series 的字符串处理方法。
+// 所有方法按 Unicode 字符(rune)而不是字节处理字符串，NaN 元素的结果仍为 NaN。
+type StringAccessor struct {
+	series Series
+}
+
+// Text 返回 Series 的字符串处理方法集合。
+func (s Series) Text() StringAccessor {
+	return StringAccessor{series: s}
+}
+
+// PadSide 表示 Pad 填充字符的位置。
+type PadSide string
+
+// 支持的填充位置
+const (
+	PadLeft  PadSide = "left"  // 在左侧填充
+	PadRight PadSide = "right" // 在右侧填充
+	PadBoth  PadSide = "both"  // 在两侧填充
+)
+
+// Lower 将每个元素转换为小写。
+func (a StringAccessor) Lower() Series {
+	return a.apply("lower", String, func(s string) interface{} { return strings.ToLower(s) })
+}
+
+// Upper 将每个元素转换为大写。
+func (a StringAccessor) Upper() Series {
+	return a.apply("upper", String, func(s string) interface{} { return strings.ToUpper(s) })
+}
+
+// Trim 去除每个元素首尾的空白字符。
+func (a StringAccessor) Trim() Series {
+	return a.apply("trim", String, func(s string) interface{} { return strings.TrimSpace(s) })
+}
+
+// Len 返回每个元素包含的 Unicode 字符数，结果为 Int 类型的 Series。
+func (a StringAccessor) Len() Series {
+	return a.apply("len", Int, func(s string) interface{} { return utf8.RuneCountInString(s) })
+}
+
+// Contains 检查每个元素是否包含子串 substr，结果为 Bool 类型的 Series。
+func (a StringAccessor) Contains(substr string) Series {
+	return a.apply("contains", Bool, func(s string) interface{} { return strings.Contains(s, substr) })
+}
+
+// StartsWith 检查每个元素是否以 prefix 开头，结果为 Bool 类型的 Series。
+func (a StringAccessor) StartsWith(prefix string) Series {
+	return a.apply("starts with", Bool, func(s string) interface{} { return strings.HasPrefix(s, prefix) })
+}
+
+// EndsWith 检查每个元素是否以 suffix 结尾，结果为 Bool 类型的 Series。
+func (a StringAccessor) EndsWith(suffix string) Series {
+	return a.apply("ends with", Bool, func(s string) interface{} { return strings.HasSuffix(s, suffix) })
+}
+
+// Replace 将每个元素中所有的 old 替换为 new。
+func (a StringAccessor) Replace(old, new string) Series {
+	return a.apply("replace", String, func(s string) interface{} { return strings.ReplaceAll(s, old, new) })
+}
+
+// Match 检查每个元素是否匹配正则表达式 pattern，结果为 Bool 类型的 Series。
+func (a StringAccessor) Match(pattern string) Series {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return a.failed("match", Bool, err)
+	}
+	return a.apply("match", Bool, func(s string) interface{} { return re.MatchString(s) })
+}
+
+// Extract 使用正则表达式 pattern 的捕获组从每个元素中提取子串，每个捕获组对应返回一个 String Series。
+// 命名捕获组以组名命名，其他捕获组命名为 "<Series 名称>_<组序号>"。不匹配的元素结果为 NaN。
+func (a StringAccessor) Extract(pattern string) []Series {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return []Series{a.failed("extract", String, err)}
+	}
+	if re.NumSubexp() == 0 {
+		return []Series{a.failed("extract", String, NewError(ErrInvalidArgument, "", "no_capture_groups"))}
+	}
+	if err := a.check(); err != nil {
+		return []Series{a.failed("extract", String, err)}
+	}
+	s := a.series
+	columns := make([][]interface{}, re.NumSubexp())
+	for k := range columns {
+		columns[k] = make([]interface{}, s.Len())
+	}
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		match := re.FindStringSubmatch(e.String())
+		if match == nil {
+			continue
+		}
+		for k := range columns {
+			columns[k][i] = match[k+1]
+		}
+	}
+	ret := make([]Series, len(columns))
+	for k, values := range columns {
+		name := re.SubexpNames()[k+1]
+		if name == "" {
+			name = fmt.Sprintf("%s_%d", s.Name, k+1)
+		}
+		ret[k] = New(values, String, name)
+	}
+	return ret
+}
+
+// Split 使用分隔符 sep 将每个元素最多拆分为 n 个部分(n <= 0 时不限制)，每个部分对应返回一个
+// String Series，与 Extract 相同命名为 "<Series 名称>_<序号>"，序号从 1 开始。部分数量不足的元素在多余的列中为 NaN。
+func (a StringAccessor) Split(sep string, n int) []Series {
+	if err := a.check(); err != nil {
+		return []Series{a.failed("split", String, err)}
+	}
+	if n <= 0 {
+		n = -1
+	}
+	s := a.series
+	parts := make([][]string, s.Len())
+	ncols := 0
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		parts[i] = strings.SplitN(e.String(), sep, n)
+		if len(parts[i]) > ncols {
+			ncols = len(parts[i])
+		}
+	}
+	ret := make([]Series, ncols)
+	for k := 0; k < ncols; k++ {
+		values := make([]interface{}, s.Len())
+		for i, p := range parts {
+			if k < len(p) {
+				values[i] = p[k]
+			}
+		}
+		ret[k] = New(values, String, fmt.Sprintf("%s_%d", s.Name, k+1))
+	}
+	return ret
+}
+
+// Pad 使用字符 fill 将每个元素填充到 width 个 Unicode 字符，side 决定填充的位置。
+// 已经达到 width 的元素保持不变。
+func (a StringAccessor) Pad(width int, side PadSide, fill rune) Series {
+	switch side {
+	case PadLeft, PadRight, PadBoth:
+	default:
+		return a.failed("pad", String, NewError(ErrInvalidArgument, "", "unknown_pad_side", side))
+	}
+	return a.apply("pad", String, func(s string) interface{} {
+		n := width - utf8.RuneCountInString(s)
+		if n <= 0 {
+			return s
+		}
+		switch side {
+		case PadLeft:
+			return strings.Repeat(string(fill), n) + s
+		case PadRight:
+			return s + strings.Repeat(string(fill), n)
+		}
+		left := n / 2
+		return strings.Repeat(string(fill), left) + s + strings.Repeat(string(fill), n-left)
+	})
+}
+
+// Slice 返回每个元素中从第 start 个到第 stop-1 个 Unicode 字符组成的子串。
+// 负数索引从末尾开始计算，超出范围的索引被截断到字符串的边界。
+func (a StringAccessor) Slice(start, stop int) Series {
+	return a.apply("slice", String, func(s string) interface{} {
+		runes := []rune(s)
+		clamp := func(i int) int {
+			if i < 0 {
+				i += len(runes)
+			}
+			if i < 0 {
+				return 0
+			}
+			if i > len(runes) {
+				return len(runes)
+			}
+			return i
+		}
+		i, j := clamp(start), clamp(stop)
+		if i >= j {
+			return ""
+		}
+		return string(runes[i:j])
+	})
+}
+
+// apply 对每个非 NaN 元素应用 f，并返回类型为 t 的新 Series。
+func (a StringAccessor) apply(op string, t Type, f func(string) interface{}) Series {
+	if err := a.check(); err != nil {
+		return a.failed(op, t, err)
+	}
+	s := a.series
+	values := make([]interface{}, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		values[i] = f(e.String())
+	}
+	return New(values, t, s.Name)
+}
+
+// check 检查 Series 是否可以进行字符串处理。
+func (a StringAccessor) check() error {
+	if a.series.Err != nil {
+		return a.series.Err
+	}
+	if a.series.t != String && a.series.t != Categorical {
+		return NewError(ErrUnknownType, "", "unsupported_type", a.series.t)
+	}
+	return nil
+}
+
+// failed 返回带有错误信息的空 Series。
+func (a StringAccessor) failed(op string, t Type, err error) Series {
+	ret := New([]string{}, t, a.series.Name)
+	ret.Err = WrapError(op, err)
+	return ret
+}
@@ -0,0 +1,91 @@
+package series
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStringAccessor(t *testing.T) {
+	s := New([]interface{}{" 张三 ", "Hello World", nil, "ÉCOLE"}, String, "s")
+	tests := []struct {
+		name string
+		got  Series
+		typ  Type
+		want []string
+	}{
+		{"lower", s.Text().Lower(), String, []string{" 张三 ", "hello world", "NaN", "école"}},
+		{"upper", s.Text().Upper(), String, []string{" 张三 ", "HELLO WORLD", "NaN", "ÉCOLE"}},
+		{"trim", s.Text().Trim(), String, []string{"张三", "Hello World", "NaN", "ÉCOLE"}},
+		{"len in runes", s.Text().Len(), Int, []string{"4", "11", "NaN", "5"}},
+		{"contains", s.Text().Contains("张"), Bool, []string{"true", "false", "NaN", "false"}},
+		{"starts with", s.Text().StartsWith("He"), Bool, []string{"false", "true", "NaN", "false"}},
+		{"ends with", s.Text().EndsWith("LE"), Bool, []string{"false", "false", "NaN", "true"}},
+		{"replace", s.Text().Replace("o", "0"), String, []string{" 张三 ", "Hell0 W0rld", "NaN", "ÉCOLE"}},
+		{"match", s.Text().Match(`^\p{Han}`), Bool, []string{"false", "false", "NaN", "false"}},
+		{"slice", s.Text().Slice(1, 3), String, []string{"张三", "el", "NaN", "CO"}},
+		{"slice negative", s.Text().Slice(-3, 100), String, []string{"张三 ", "rld", "NaN", "OLE"}},
+		{"pad left", New([]string{"张", "abc"}, String, "p").Text().Pad(3, PadLeft, '*'), String, []string{"**张", "abc"}},
+		{"pad both", New([]string{"a"}, String, "p").Text().Pad(4, PadBoth, '-'), String, []string{"-a--"}},
+		{"categorical", New([]string{"A", "b"}, Categorical, "c").Text().Lower(), String, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.got, tt.typ, tt.want)
+		})
+	}
+}
+
+func TestStringAccessorExtractSplit(t *testing.T) {
+	s := New([]interface{}{"北京-100", "上海-20-x", nil, "广州"}, String, "city")
+
+	extracted := s.Text().Extract(`^(?P<name>[^-]+)-(\d+)`)
+	if len(extracted) != 2 {
+		t.Fatalf("extract returned %d series, want 2", len(extracted))
+	}
+	if extracted[0].Name != "name" || extracted[1].Name != "city_2" {
+		t.Errorf("extract names = %q %q, want name city_2", extracted[0].Name, extracted[1].Name)
+	}
+	checkSeries(t, extracted[1], String, []string{"100", "20", "NaN", "NaN"})
+
+	tests := []struct {
+		name  string
+		n     int
+		names []string
+		last  []string
+	}{
+		{"no limit", -1, []string{"city_1", "city_2", "city_3"}, []string{"NaN", "x", "NaN", "NaN"}},
+		{"zero is no limit", 0, []string{"city_1", "city_2", "city_3"}, []string{"NaN", "x", "NaN", "NaN"}},
+		{"limit", 2, []string{"city_1", "city_2"}, []string{"100", "20-x", "NaN", "NaN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := s.Text().Split("-", tt.n)
+			if len(parts) != len(tt.names) {
+				t.Fatalf("split returned %d series, want %d", len(parts), len(tt.names))
+			}
+			for k, p := range parts {
+				if p.Name != tt.names[k] {
+					t.Errorf("name %d = %q, want %q", k, p.Name, tt.names[k])
+				}
+			}
+			checkSeries(t, parts[0], String, []string{"北京", "上海", "NaN", "广州"})
+			checkSeries(t, parts[len(parts)-1], String, tt.last)
+		})
+	}
+}
+
+func TestStringAccessorErrors(t *testing.T) {
+	s := New([]string{"a"}, String, "s")
+	if got := New([]int{1}, Int, "i").Text().Lower(); !errors.Is(got.Err, ErrUnknownType) {
+		t.Errorf("int series: err = %v, want ErrUnknownType", got.Err)
+	}
+	if got := s.Text().Match("("); got.Err == nil {
+		t.Error("invalid pattern: expected error")
+	}
+	if got := s.Text().Extract("a"); !errors.Is(got[0].Err, ErrInvalidArgument) {
+		t.Errorf("no capture groups: err = %v, want ErrInvalidArgument", got[0].Err)
+	}
+	if got := s.Text().Pad(3, "middle", ' '); !errors.Is(got.Err, ErrInvalidArgument) {
+		t.Errorf("pad side: err = %v, want ErrInvalidArgument", got.Err)
+	}
+}
@@ -1,7 +1,6 @@
 package series
 
 import (
-	"fmt"
 	"math"
 	"strings"
 )
@@ -109,7 +108,7 @@ func (e boolElement) String() string {
 // Int 方法将布尔元素转换为整数。
 func (e boolElement) Int() (int, error) {
 	if e.IsNA() {
-		return 0, fmt.Errorf("can't convert NaN to int")
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
 	}
 	if e.e {
 		return 1, nil
@@ -131,7 +130,7 @@ func (e boolElement) Float() float64 {
 // Bool 方法返回布尔元素的布尔值。
 func (e boolElement) Bool() (bool, error) {
 	if e.IsNA() {
-		return false, fmt.Errorf("can't convert NaN to bool")
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
 	}
 	return bool(e.e), nil
 }
@@ -0,0 +1,337 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// categories 是 Categorical 类型 Series 使用的类别字典。子集和副本与原 Series 共享同一个字典，
+// 共享后的字典不再修改，需要添加类别时先复制。
+type categories struct {
+	values  []string       // 按编码排列的类别
+	index   map[string]int // 类别到编码的映射
+	fixed   bool           // 类别是否固定，固定时不在类别中的值视为 NaN
+	ordered bool           // 类别是否有序，有序时按编码比较大小
+	shared  atomic.Bool    // 字典是否被多个 Series 共享
+}
+
+// newCategories 使用给定的类别创建类别字典。values 为 nil 时类别会随着元素的设置自动增加。
+func newCategories(values []string, ordered bool) *categories {
+	c := &categories{
+		index:   make(map[string]int),
+		fixed:   values != nil,
+		ordered: ordered,
+	}
+	for _, v := range values {
+		if _, ok := c.index[v]; ok {
+			continue
+		}
+		c.index[v] = len(c.values)
+		c.values = append(c.values, v)
+	}
+	return c
+}
+
+// clone 返回类别字典的副本，副本未被共享，编码与原字典相同。
+func (c *categories) clone() *categories {
+	ret := &categories{
+		values:  append([]string(nil), c.values...),
+		index:   make(map[string]int, len(c.index)),
+		fixed:   c.fixed,
+		ordered: c.ordered,
+	}
+	for v, code := range c.index {
+		ret.index[v] = code
+	}
+	return ret
+}
+
+// categoryDict 是一个 Series 的所有元素共同引用的类别字典。
+type categoryDict struct {
+	*categories
+}
+
+// share 返回引用同一个类别字典的新 categoryDict，并将字典标记为共享。
+func (d *categoryDict) share() *categoryDict {
+	d.shared.Store(true)
+	return &categoryDict{d.categories}
+}
+
+// code 返回类别的编码。类别不存在且字典未固定时添加新的类别；字典被共享时先复制，
+// 因此添加类别不会影响共享该字典的其他 Series。
+func (d *categoryDict) code(v string) (int, bool) {
+	if i, ok := d.index[v]; ok {
+		return i, true
+	}
+	if d.fixed {
+		return 0, false
+	}
+	if d.shared.Load() {
+		d.categories = d.categories.clone()
+	}
+	d.index[v] = len(d.values)
+	d.values = append(d.values, v)
+	return len(d.values) - 1, true
+}
+
+// categoricalElements 是 Categorical 类型元素的具体实现，所有元素共享同一个类别字典。
+type categoricalElements struct {
+	elements []categoricalElement
+	dict     *categoryDict
+}
+
+func (e categoricalElements) Len() int           { return len(e.elements) }
+func (e categoricalElements) Elem(i int) Element { return &e.elements[i] }
+
+// String 返回所有元素的类别，格式与其他类型的元素切片相同。
+func (e categoricalElements) String() string { return fmt.Sprint(e.elements) }
+
+// newCategoricalElements 创建 n 个使用类别字典 dict 的元素。
+func newCategoricalElements(n int, dict *categoryDict) categoricalElements {
+	elements := make([]categoricalElement, n)
+	for i := range elements {
+		elements[i].dict = dict
+	}
+	return categoricalElements{elements: elements, dict: dict}
+}
+
+// subset 返回包含 idx 对应元素的新元素数组，新元素与原元素共享类别字典，编码保持不变。
+func (e categoricalElements) subset(idx []int) categoricalElements {
+	ret := newCategoricalElements(len(idx), e.dict.share())
+	for k, i := range idx {
+		ret.elements[k].code, ret.elements[k].nan = e.elements[i].code, e.elements[i].nan
+	}
+	return ret
+}
+
+// categoricalElement 表示 Series 中的分类元素，只保存类别的编码。
+type categoricalElement struct {
+	code int32
+	nan  bool
+	dict *categoryDict
+}
+
+// 强制 categoricalElement 结构实现 Element 接口。
+var _ Element = (*categoricalElement)(nil)
+
+// Set 方法将给定的值设置为分类元素。值按照 String 类型的规则转换为字符串后查找对应的类别。
+// 如果值为 "NaN"，或者类别固定且值不在类别中，则标记为 NaN。
+func (e *categoricalElement) Set(value interface{}) {
+	e.nan = false
+	if c, ok := value.(*categoricalElement); ok && c.dict.categories == e.dict.categories {
+		e.code, e.nan = c.code, c.nan
+		return
+	}
+	var s stringElement
+	s.Set(value)
+	if s.IsNA() {
+		e.nan = true
+		return
+	}
+	code, ok := e.dict.code(s.e)
+	if !ok {
+		e.nan = true
+		return
+	}
+	e.code = int32(code)
+}
+
+// Copy 方法返回分类元素的副本。
+func (e categoricalElement) Copy() Element {
+	return &categoricalElement{e.code, e.nan, e.dict}
+}
+
+// IsNA 方法检查分类元素是否为 NaN。
+func (e categoricalElement) IsNA() bool {
+	return e.nan
+}
+
+// Type 方法返回分类元素的类型。
+func (e categoricalElement) Type() Type {
+	return Categorical
+}
+
+// Val 方法返回分类元素的类别。
+func (e categoricalElement) Val() ElementValue {
+	if e.IsNA() {
+		return nil
+	}
+	return e.dict.values[e.code]
+}
+
+// String 方法返回分类元素的类别。
+func (e categoricalElement) String() string {
+	if e.IsNA() {
+		return "NaN"
+	}
+	return e.dict.values[e.code]
+}
+
+// Int 方法将分类元素的类别转换为整数。
+func (e categoricalElement) Int() (int, error) {
+	if e.IsNA() {
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
+	}
+	i, err := strconv.Atoi(e.String())
+	if err != nil {
+		return 0, NewError(ErrConversion, "", "convert_value", Categorical, e.String(), "int").Wrap(err)
+	}
+	return i, nil
+}
+
+// Float 方法将分类元素的类别转换为浮点数。
+func (e categoricalElement) Float() float64 {
+	if e.IsNA() {
+		return math.NaN()
+	}
+	f, err := strconv.ParseFloat(e.String(), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// Bool 方法将分类元素的类别转换为布尔值。
+func (e categoricalElement) Bool() (bool, error) {
+	if e.IsNA() {
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
+	}
+	switch strings.ToLower(e.String()) {
+	case "true", "t", "1":
+		return true, nil
+	case "false", "f", "0":
+		return false, nil
+	}
+	return false, NewError(ErrConversion, "", "convert_value", Categorical, e.String(), "bool")
+}
+
+// compare 比较分类元素与另一个元素，返回 -1、0 或 1。类别有序时按编码比较，否则按字符串比较。
+// 任意一方为 NaN，或者有序类别中找不到另一个元素的值时，ok 为 false。
+func (e categoricalElement) compare(elem Element) (cmp int, ok bool) {
+	if e.IsNA() || elem.IsNA() {
+		return 0, false
+	}
+	if !e.dict.ordered {
+		return strings.Compare(e.String(), elem.String()), true
+	}
+	if c, isCat := elem.(*categoricalElement); isCat && c.dict.categories == e.dict.categories {
+		return compareInts(int(e.code), int(c.code)), true
+	}
+	code, found := e.dict.index[elem.String()]
+	if !found {
+		return 0, false
+	}
+	return compareInts(int(e.code), code), true
+}
+
+// compareInts 比较两个整数，返回 -1、0 或 1。
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Eq 方法检查分类元素是否等于另一个元素。
+func (e categoricalElement) Eq(elem Element) bool {
+	if e.IsNA() || elem.IsNA() {
+		return false
+	}
+	if c, ok := elem.(*categoricalElement); ok && c.dict.categories == e.dict.categories {
+		return e.code == c.code
+	}
+	return e.String() == elem.String()
+}
+
+// Neq 方法检查分类元素是否不等于另一个元素。
+func (e categoricalElement) Neq(elem Element) bool {
+	if e.IsNA() || elem.IsNA() {
+		return false
+	}
+	return !e.Eq(elem)
+}
+
+// Less 方法检查分类元素是否小于另一个元素。
+func (e categoricalElement) Less(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp < 0
+}
+
+// LessEq 方法检查分类元素是否小于或等于另一个元素。
+func (e categoricalElement) LessEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp <= 0
+}
+
+// Greater 方法检查分类元素是否大于另一个元素。
+func (e categoricalElement) Greater(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp > 0
+}
+
+// GreaterEq 方法检查分类元素是否大于或等于另一个元素。
+func (e categoricalElement) GreaterEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp >= 0
+}
+
+// Categoricals 是 Categorical Series 的构造函数，类别按照值第一次出现的顺序确定。
+func Categoricals(values interface{}) Series {
+	return New(values, Categorical, "")
+}
+
+// NewCategorical 使用给定的类别创建 Categorical Series。不在 categories 中的值被标记为 NaN；
+// categories 为 nil 时类别按照值第一次出现的顺序确定。ordered 为 true 时类别按照编码顺序比较大小，
+// 影响 Less、Greater 以及排序。
+func NewCategorical(values interface{}, categories []string, ordered bool, name string) Series {
+	strs := New(values, String, name)
+	elements := newCategoricalElements(strs.Len(), &categoryDict{newCategories(categories, ordered)})
+	for i := 0; i < strs.Len(); i++ {
+		elements.Elem(i).Set(strs.elements.Elem(i))
+	}
+	return Series{
+		Name:     name,
+		elements: elements,
+		t:        Categorical,
+	}
+}
+
+// Categories 方法返回 Categorical Series 的所有类别，按编码排列。其他类型返回 nil。
+func (s Series) Categories() []string {
+	e, ok := s.elements.(categoricalElements)
+	if !ok {
+		return nil
+	}
+	ret := make([]string, len(e.dict.values))
+	copy(ret, e.dict.values)
+	return ret
+}
+
+// Codes 方法返回 Categorical Series 中每个元素的类别编码，NaN 元素的编码为 -1。其他类型返回 nil。
+func (s Series) Codes() []int {
+	e, ok := s.elements.(categoricalElements)
+	if !ok {
+		return nil
+	}
+	ret := make([]int, e.Len())
+	for i, el := range e.elements {
+		if el.nan {
+			ret[i] = -1
+			continue
+		}
+		ret[i] = int(el.code)
+	}
+	return ret
+}
+
+// Ordered 方法返回 Categorical Series 的类别是否有序。
+func (s Series) Ordered() bool {
+	e, ok := s.elements.(categoricalElements)
+	return ok && e.dict.ordered
+}
@@ -0,0 +1,102 @@
+package series
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategorical(t *testing.T) {
+	s := New([]interface{}{"red", "blue", nil, "red", "NaN"}, Categorical, "colour")
+	checkSeries(t, s, Categorical, []string{"red", "blue", "NaN", "red", "NaN"})
+	if want := []string{"red", "blue"}; !reflect.DeepEqual(s.Categories(), want) {
+		t.Errorf("categories = %v, want %v", s.Categories(), want)
+	}
+	if want := []int{0, 1, -1, 0, -1}; !reflect.DeepEqual(s.Codes(), want) {
+		t.Errorf("codes = %v, want %v", s.Codes(), want)
+	}
+	checkSeries(t, New(s, String, "colour"), String, []string{"red", "blue", "NaN", "red", "NaN"})
+	checkSeries(t, New(New([]string{"a", "b"}, String, "x"), Categorical, "x"), Categorical, []string{"a", "b"})
+}
+
+func TestCategoricalOrdered(t *testing.T) {
+	sizes := []string{"small", "medium", "large"}
+	s := NewCategorical([]string{"large", "small", "huge", "medium"}, sizes, true, "size")
+	checkSeries(t, s, Categorical, []string{"large", "small", "NaN", "medium"})
+	if !s.Ordered() {
+		t.Fatal("categories should be ordered")
+	}
+	if want := []int{1, 3, 0, 2}; !reflect.DeepEqual(s.Order(false), want) {
+		t.Errorf("order = %v, want %v", s.Order(false), want)
+	}
+	tests := []struct {
+		name string
+		cmp  Comparator
+		want []bool
+	}{
+		{"greater", Greater, []bool{true, false, false, false}},
+		{"less eq", LessEq, []bool{false, true, false, true}},
+		{"eq", Eq, []bool{false, false, false, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Compare(tt.cmp, "medium").Bool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	unordered := NewCategorical([]string{"b", "a", "c"}, nil, false, "x")
+	if want := []int{1, 0, 2}; !reflect.DeepEqual(unordered.Order(false), want) {
+		t.Errorf("unordered order = %v, want %v", unordered.Order(false), want)
+	}
+}
+
+func TestCategoricalIndependentCopies(t *testing.T) {
+	s := New([]string{"x", "y"}, Categorical, "c")
+	tests := []struct {
+		name string
+		f    func() Series
+	}{
+		{"copy", s.Copy},
+		{"subset", func() Series { return s.Subset([]int{1}) }},
+		{"empty", s.Empty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.f()
+			c.Append([]string{"z"})
+			if want := []string{"x", "y"}; !reflect.DeepEqual(s.Categories(), want) {
+				t.Errorf("original categories = %v, want %v", s.Categories(), want)
+			}
+			if got := c.Categories(); got[len(got)-1] != "z" {
+				t.Errorf("copy categories = %v, want z appended", got)
+			}
+		})
+	}
+
+	t.Run("original", func(t *testing.T) {
+		o := New([]string{"x", "y"}, Categorical, "c")
+		c := o.Subset([]int{0, 1})
+		if o.elements.(categoricalElements).dict.categories != c.elements.(categoricalElements).dict.categories {
+			t.Error("subset should share the categories until one of them adds a category")
+		}
+		o.Append([]string{"w"})
+		if want := []string{"x", "y"}; !reflect.DeepEqual(c.Categories(), want) {
+			t.Errorf("subset categories = %v, want %v", c.Categories(), want)
+		}
+		checkSeries(t, o, Categorical, []string{"x", "y", "w"})
+		checkSeries(t, c, Categorical, []string{"x", "y"})
+	})
+
+	fixed := NewCategorical([]string{"a"}, []string{"a", "b"}, true, "f")
+	sub := fixed.Subset([]int{0})
+	sub.Append([]string{"b", "c"})
+	checkSeries(t, sub, Categorical, []string{"a", "b", "NaN"})
+	if !sub.Ordered() {
+		t.Error("subset should keep ordered categories")
+	}
+}
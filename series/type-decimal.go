@@ -0,0 +1,414 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// decimalSpec 是 Decimal 类型 Series 共享的精度和小数位数。
+type decimalSpec struct {
+	precision int // 最大有效位数，0 表示不限制
+	scale     int // 固定的小数位数，负数表示保留每个值自身的小数位数
+}
+
+// maxDecimalScale 是小数位数和指数的上限。超出上限的字符串视为无法解析，超出上限的规格和舍入位数无效，
+// 以免 "1e-50000000" 这样的输入在 pow10 和 rescale 中耗费大量时间和内存。
+const maxDecimalScale = 1000
+
+// freeDecimal 是不限制精度和小数位数的 Decimal 规格。
+var freeDecimal = &decimalSpec{scale: -1}
+
+// DecimalOf 返回用于创建固定精度和小数位数的 Decimal Series 的类型，可以传给 New 或 dataframe 包的 WithTypes，
+// 也可以写在结构体标签中，例如 "decimal(10,2)"。precision 为 0 表示不限制有效位数。
+// 设置元素时，小数位数多于 scale 的值按照 RoundHalfEven 舍入，有效位数超过 precision 的值标记为 NaN。
+// scale 不能超过 1000。创建的 Series 的类型为 Decimal。
+func DecimalOf(precision, scale int) Type {
+	return Type(fmt.Sprintf("%s(%d,%d)", Decimal, precision, scale))
+}
+
+// IsDecimal 检查 t 是否为 Decimal 或 DecimalOf 返回的类型。
+func IsDecimal(t Type) bool {
+	_, ok := decimalSpecOf(t)
+	return ok
+}
+
+// decimalSpecOf 返回类型 t 对应的 Decimal 规格，t 不是 Decimal 类型时返回 false。
+func decimalSpecOf(t Type) (*decimalSpec, bool) {
+	if t == Decimal {
+		return freeDecimal, true
+	}
+	var precision, scale int
+	if _, err := fmt.Sscanf(string(t), string(Decimal)+"(%d,%d)", &precision, &scale); err != nil {
+		return nil, false
+	}
+	if DecimalOf(precision, scale) != t || precision < 0 || scale < 0 || scale > maxDecimalScale || (precision > 0 && scale > precision) {
+		return nil, false
+	}
+	return &decimalSpec{precision: precision, scale: scale}, true
+}
+
+// decimalElements 是 Decimal 类型元素的具体实现，所有元素共享同一个规格。
+type decimalElements struct {
+	elements []decimalElement
+	spec     *decimalSpec
+}
+
+func (e decimalElements) Len() int           { return len(e.elements) }
+func (e decimalElements) Elem(i int) Element { return &e.elements[i] }
+
+// String 返回所有元素的值，格式与其他类型的元素切片相同。
+func (e decimalElements) String() string { return fmt.Sprint(e.elements) }
+
+// newDecimalElements 创建 n 个使用规格 spec 的元素。
+func newDecimalElements(n int, spec *decimalSpec) decimalElements {
+	elements := make([]decimalElement, n)
+	for i := range elements {
+		elements[i].spec = spec
+	}
+	return decimalElements{elements: elements, spec: spec}
+}
+
+// conversionError 返回第一个因值的类型不支持而被标记为 NaN 的元素的错误，没有时返回 nil。
+func (e decimalElements) conversionError(op string) error {
+	for i, el := range e.elements {
+		if el.unsupported {
+			return NewError(ErrConversion, op, "unsupported_value", i, Decimal)
+		}
+	}
+	return nil
+}
+
+// decimalElement 表示 Series 中的定点小数元素，值为 v × 10^-scale。v 创建后不再修改，可以在元素之间共享。
+type decimalElement struct {
+	v           *big.Int
+	scale       int
+	nan         bool
+	unsupported bool // 最近一次设置的值的类型无法转换为 Decimal
+	spec        *decimalSpec
+}
+
+// 强制 decimalElement 结构实现 Element 接口。
+var _ Element = (*decimalElement)(nil)
+
+// Set 方法将给定的值设置为定点小数元素。字符串按照十进制精确解析，不经过 float64；
+// 浮点数使用能精确表示该数值的最短十进制形式。如果值为 "NaN"、无法解析，或者超出规格的精度，则标记为 NaN；
+// 类型不支持的值同样标记为 NaN，并被 New 和 Append 报告为错误。
+func (e *decimalElement) Set(value interface{}) {
+	e.nan, e.unsupported = false, false
+	if d, ok := value.(*decimalElement); ok && d.spec == e.spec {
+		e.v, e.scale, e.nan = d.v, d.scale, d.nan
+		return
+	}
+	v, scale, ok := decimalValue(value)
+	if !ok {
+		e.nan, e.unsupported = true, !decimalType(value)
+		return
+	}
+	e.v, e.scale = v, scale
+	e.fit()
+}
+
+// fit 按照规格舍入元素的值并检查有效位数，超出精度时标记为 NaN。
+func (e *decimalElement) fit() {
+	if e.spec.scale >= 0 && e.scale != e.spec.scale {
+		e.v, e.scale = rescale(e.v, e.scale, e.spec.scale, RoundHalfEven), e.spec.scale
+	}
+	if e.spec.precision > 0 && len(new(big.Int).Abs(e.v).String()) > e.spec.precision {
+		e.nan = true
+	}
+}
+
+// decimalValue 将值转换为整数值和小数位数，无法转换时返回 false。
+func decimalValue(value interface{}) (*big.Int, int, bool) {
+	switch val := value.(type) {
+	case string:
+		return parseDecimal(val)
+	case int:
+		return big.NewInt(int64(val)), 0, true
+	case int8:
+		return big.NewInt(int64(val)), 0, true
+	case int16:
+		return big.NewInt(int64(val)), 0, true
+	case int32:
+		return big.NewInt(int64(val)), 0, true
+	case int64:
+		return big.NewInt(val), 0, true
+	case uint:
+		return new(big.Int).SetUint64(uint64(val)), 0, true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(val)), 0, true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(val)), 0, true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(val)), 0, true
+	case uint64:
+		return new(big.Int).SetUint64(val), 0, true
+	case float32:
+		if math.IsNaN(float64(val)) || math.IsInf(float64(val), 0) {
+			return nil, 0, false
+		}
+		return parseDecimal(strconv.FormatFloat(float64(val), 'f', -1, 32))
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return nil, 0, false
+		}
+		return parseDecimal(strconv.FormatFloat(val, 'f', -1, 64))
+	case bool:
+		if val {
+			return big.NewInt(1), 0, true
+		}
+		return big.NewInt(0), 0, true
+	case *decimalElement:
+		if val.IsNA() {
+			return nil, 0, false
+		}
+		return val.v, val.scale, true
+	case Element:
+		if val.IsNA() {
+			return nil, 0, false
+		}
+		if val.Type() == Float {
+			return decimalValue(val.Float())
+		}
+		return parseDecimal(val.String())
+	}
+	return nil, 0, false
+}
+
+// decimalType 检查 decimalValue 是否支持值的类型。nil 表示 NaN，视为支持。
+func decimalType(value interface{}) bool {
+	switch value.(type) {
+	case nil, string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool, Element:
+		return true
+	}
+	return false
+}
+
+// parseDecimal 精确解析十进制数，支持符号、小数点和指数，例如 "-1.25"、".5" 和 "1.5e3"。
+// 小数位数或指数补零的位数超过 maxDecimalScale 时无法解析。
+func parseDecimal(s string) (*big.Int, int, bool) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, 0, false
+		}
+		mantissa, exp = s[:i], e
+	}
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" {
+		return nil, 0, false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return nil, 0, false
+		}
+	}
+	// 在计算 10 的幂之前检查位数，len(fracPart) 不超过输入的长度，相减不会溢出
+	if exp > maxDecimalScale+len(fracPart) || exp < len(fracPart)-maxDecimalScale {
+		return nil, 0, false
+	}
+	v, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return nil, 0, false
+	}
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		return v.Mul(v, pow10(-scale)), 0, true
+	}
+	return v, scale, true
+}
+
+// pow10 返回 10^n。
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rescale 将小数位数为 from 的整数值 v 转换为小数位数 to，需要舍弃数位时按照 mode 舍入。
+func rescale(v *big.Int, from, to int, mode RoundingMode) *big.Int {
+	if to >= from {
+		return new(big.Int).Mul(v, pow10(to-from))
+	}
+	return roundQuo(v, pow10(from-to), mode)
+}
+
+// roundQuo 返回 num / den 按照 mode 舍入后的整数，den 必须为正数。
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// away 表示远离零的方向
+	away := false
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = num.Sign() < 0
+	case RoundCeiling:
+		away = num.Sign() > 0
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven:
+		half := new(big.Int).Abs(r)
+		switch half.Lsh(half, 1).Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || (mode == RoundHalfEven && q.Bit(0) == 1)
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return q
+}
+
+// Copy 方法返回定点小数元素的副本。
+func (e decimalElement) Copy() Element {
+	return &decimalElement{v: e.v, scale: e.scale, nan: e.nan, spec: e.spec}
+}
+
+// IsNA 方法检查定点小数元素是否为 NaN。
+func (e decimalElement) IsNA() bool {
+	return e.nan || e.v == nil
+}
+
+// Type 方法返回定点小数元素的类型。
+func (e decimalElement) Type() Type {
+	return Decimal
+}
+
+// Val 方法返回定点小数元素的标准字符串形式。
+func (e decimalElement) Val() ElementValue {
+	if e.IsNA() {
+		return nil
+	}
+	return e.String()
+}
+
+// String 方法返回定点小数元素的标准字符串形式，小数部分的位数等于元素的小数位数，例如 "-0.50"。
+func (e decimalElement) String() string {
+	if e.IsNA() {
+		return "NaN"
+	}
+	digits := new(big.Int).Abs(e.v).String()
+	sign := ""
+	if e.v.Sign() < 0 {
+		sign = "-"
+	}
+	if e.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= e.scale {
+		digits = strings.Repeat("0", e.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-e.scale] + "." + digits[len(digits)-e.scale:]
+}
+
+// Int 方法将定点小数元素转换为整数，有小数部分或超出 int 范围时返回错误。
+func (e decimalElement) Int() (int, error) {
+	if e.IsNA() {
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
+	}
+	q, r := new(big.Int).QuoRem(e.v, pow10(e.scale), new(big.Int))
+	if r.Sign() != 0 || !q.IsInt64() || q.Int64() != int64(int(q.Int64())) {
+		return 0, NewError(ErrConversion, "", "convert_value", Decimal, e.String(), "int")
+	}
+	return int(q.Int64()), nil
+}
+
+// Float 方法返回最接近定点小数元素的浮点数。
+func (e decimalElement) Float() float64 {
+	if e.IsNA() {
+		return math.NaN()
+	}
+	f, _ := new(big.Rat).SetFrac(e.v, pow10(e.scale)).Float64()
+	return f
+}
+
+// Bool 方法将定点小数元素转换为布尔值，只有 1 和 0 可以转换。
+func (e decimalElement) Bool() (bool, error) {
+	if e.IsNA() {
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
+	}
+	i, err := e.Int()
+	if err == nil && (i == 0 || i == 1) {
+		return i == 1, nil
+	}
+	return false, NewError(ErrConversion, "", "convert_value", Decimal, e.String(), "bool")
+}
+
+// compare 精确比较定点小数元素与另一个元素，返回 -1、0 或 1。任意一方为 NaN 或另一个元素无法转换为小数时，ok 为 false。
+func (e decimalElement) compare(elem Element) (cmp int, ok bool) {
+	if e.IsNA() {
+		return 0, false
+	}
+	v, scale, ok := decimalValue(elem)
+	if !ok {
+		return 0, false
+	}
+	a, b, _ := alignDecimals(e.v, e.scale, v, scale)
+	return a.Cmp(b), true
+}
+
+// alignDecimals 将两个定点小数转换为相同的小数位数，返回转换后的整数值和小数位数。
+func alignDecimals(a *big.Int, as int, b *big.Int, bs int) (*big.Int, *big.Int, int) {
+	switch {
+	case as < bs:
+		return rescale(a, as, bs, RoundDown), b, bs
+	case as > bs:
+		return a, rescale(b, bs, as, RoundDown), as
+	}
+	return a, b, as
+}
+
+// Eq 方法检查定点小数元素是否等于另一个元素。
+func (e decimalElement) Eq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp == 0
+}
+
+// Neq 方法检查定点小数元素是否不等于另一个元素。
+func (e decimalElement) Neq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp != 0
+}
+
+// Less 方法检查定点小数元素是否小于另一个元素。
+func (e decimalElement) Less(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp < 0
+}
+
+// LessEq 方法检查定点小数元素是否小于或等于另一个元素。
+func (e decimalElement) LessEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp <= 0
+}
+
+// Greater 方法检查定点小数元素是否大于另一个元素。
+func (e decimalElement) Greater(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp > 0
+}
+
+// GreaterEq 方法检查定点小数元素是否大于或等于另一个元素。
+func (e decimalElement) GreaterEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp >= 0
+}
+
+// Decimals 是 Decimal Series 的构造函数，每个值保留自身的小数位数。
+func Decimals(values interface{}) Series {
+	return New(values, Decimal, "")
+}
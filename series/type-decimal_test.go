@@ -0,0 +1,193 @@
+package series
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecimalParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		typ  Type
+		want []string
+	}{
+		{"free", []string{"1.25", "-0.5", ".5", "+3", "1.5e3", "12e-3", "NaN"}, Decimal,
+			[]string{"1.25", "-0.5", "0.5", "3", "1500", "0.012", "NaN"}},
+		{"fixed scale", []string{"1", "1.005", "1.015", "-2.345"}, DecimalOf(10, 2),
+			[]string{"1.00", "1.00", "1.02", "-2.34"}},
+		{"precision", []string{"12345.6", "123.45"}, DecimalOf(5, 2),
+			[]string{"NaN", "123.45"}},
+		{"invalid", []string{"1..2", "e5", "1e", "abc", "1.2.3"}, Decimal,
+			[]string{"NaN", "NaN", "NaN", "NaN", "NaN"}},
+		{"exponent bound", []string{"1e1000", "1e-1000", "1e1001", "1e-1001", "1e-50000000", "1e99999999999999999999"}, Decimal,
+			[]string{"1" + strings.Repeat("0", 1000), "0." + strings.Repeat("0", 999) + "1", "NaN", "NaN", "NaN", "NaN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.in, tt.typ, "")
+			if got := s.Records(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+			if s.Type() != Decimal {
+				t.Errorf("type = %v, want %v", s.Type(), Decimal)
+			}
+		})
+	}
+}
+
+func TestDecimalNativeTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want []string
+	}{
+		{"signed", []interface{}{int8(-8), int16(16), int32(-32), int64(math.MinInt64)}, []string{"-8", "16", "-32", "-9223372036854775808"}},
+		{"unsigned", []interface{}{uint(1), uint8(8), uint16(16), uint32(32), uint64(math.MaxUint64)},
+			[]string{"1", "8", "16", "32", "18446744073709551615"}},
+		{"uint64 slice", []uint64{5, 7}, []string{"5", "7"}},
+		{"float32", []float32{1.5, 0.1, float32(math.NaN())}, []string{"1.5", "0.1", "NaN"}},
+		{"nil", []interface{}{nil, 1}, []string{"NaN", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.in, Decimal, "")
+			if s.Err != nil {
+				t.Fatalf("err = %v", s.Err)
+			}
+			checkSeries(t, s, Decimal, tt.want)
+		})
+	}
+
+	s := New([]interface{}{1, struct{}{}}, Decimal, "")
+	if !errors.Is(s.Err, ErrConversion) {
+		t.Errorf("unsupported err = %v, want %v", s.Err, ErrConversion)
+	}
+	a := New([]int{1}, Decimal, "")
+	a.Append([]complex128{1})
+	if !errors.Is(a.Err, ErrConversion) {
+		t.Errorf("append err = %v, want %v", a.Err, ErrConversion)
+	}
+}
+
+func TestDecimalExponentIsFast(t *testing.T) {
+	start := time.Now()
+	s := New([]string{"1e-50000000", "1e50000000"}, DecimalOf(10, 2), "")
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("parsing took %v", d)
+	}
+	if !s.Elem(0).IsNA() || !s.Elem(1).IsNA() {
+		t.Errorf("records = %v, want NaN", s.Records())
+	}
+}
+
+func TestDecimalOf(t *testing.T) {
+	tests := []struct {
+		t  Type
+		ok bool
+	}{
+		{Decimal, true},
+		{DecimalOf(10, 2), true},
+		{DecimalOf(0, 1000), true},
+		{DecimalOf(0, 1001), false},
+		{DecimalOf(2, 3), false},
+		{"decimal(10, 2)", false},
+		{"decimal(-1,0)", false},
+	}
+	for _, tt := range tests {
+		if got := IsDecimal(tt.t); got != tt.ok {
+			t.Errorf("IsDecimal(%q) = %v, want %v", tt.t, got, tt.ok)
+		}
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	s := New([]string{"2.5", "-2.5", "1.25", "-1.25", "1.21", "-1.29", "3"}, Decimal, "")
+	tests := []struct {
+		mode  RoundingMode
+		scale int
+		want  []string
+	}{
+		{RoundHalfEven, 0, []string{"2", "-2", "1", "-1", "1", "-1", "3"}},
+		{RoundHalfEven, 1, []string{"2.5", "-2.5", "1.2", "-1.2", "1.2", "-1.3", "3.0"}},
+		{RoundHalfUp, 1, []string{"2.5", "-2.5", "1.3", "-1.3", "1.2", "-1.3", "3.0"}},
+		{RoundHalfDown, 1, []string{"2.5", "-2.5", "1.2", "-1.2", "1.2", "-1.3", "3.0"}},
+		{RoundUp, 1, []string{"2.5", "-2.5", "1.3", "-1.3", "1.3", "-1.3", "3.0"}},
+		{RoundDown, 1, []string{"2.5", "-2.5", "1.2", "-1.2", "1.2", "-1.2", "3.0"}},
+		{RoundCeiling, 1, []string{"2.5", "-2.5", "1.3", "-1.2", "1.3", "-1.2", "3.0"}},
+		{RoundFloor, 1, []string{"2.5", "-2.5", "1.2", "-1.3", "1.2", "-1.3", "3.0"}},
+		{RoundHalfUp, 0, []string{"3", "-3", "1", "-1", "1", "-1", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			checkSeries(t, s.Dec().Round(tt.scale, tt.mode), Decimal, tt.want)
+		})
+	}
+
+	errs := []struct {
+		name  string
+		scale int
+		mode  RoundingMode
+	}{
+		{"negative scale", -1, RoundHalfEven},
+		{"scale too large", 1001, RoundHalfEven},
+		{"unknown mode", 1, "banker"},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Dec().RoundE(tt.scale, tt.mode); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	s := New([]string{"0.1", "0.2", "NaN"}, Decimal, "x")
+	tests := []struct {
+		name string
+		got  Series
+		want []string
+	}{
+		{"add", s.Dec().Add("0.2"), []string{"0.3", "0.4", "NaN"}},
+		{"sub", s.Dec().Sub([]string{"0.05", "1", "1"}), []string{"0.05", "-0.8", "NaN"}},
+		{"mul", s.Dec().Mul("1.5"), []string{"0.15", "0.30", "NaN"}},
+		{"div", s.Dec().Div("3", 4, RoundHalfUp), []string{"0.0333", "0.0667", "NaN"}},
+		{"div floor", New([]string{"-1"}, Decimal, "").Dec().Div("3", 2, RoundFloor), []string{"-0.34"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSeries(t, tt.got, Decimal, tt.want)
+		})
+	}
+
+	sum, err := s.Dec().Sum()
+	if err != nil || sum.String() != "0.3" {
+		t.Errorf("Sum = %v, %v; want 0.3", sum, err)
+	}
+	mean, err := s.Dec().Mean(3, RoundHalfEven)
+	if err != nil || mean.String() != "0.150" {
+		t.Errorf("Mean = %v, %v; want 0.150", mean, err)
+	}
+
+	errs := []struct {
+		name string
+		f    func() error
+		kind ErrorKind
+	}{
+		{"div by zero", func() error { _, err := s.Dec().DivE("0", 2, RoundHalfEven); return err }, ErrInvalidArgument},
+		{"length", func() error { _, err := s.Dec().AddE([]string{"1", "2"}); return err }, ErrDimensionMismatch},
+		{"not decimal", func() error { _, err := Ints([]int{1}).Dec().AddE("1"); return err }, ErrUnknownType},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f(); !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+		})
+	}
+}
@@ -89,14 +89,14 @@ func (e floatElement) String() string {
 // Int 将元素转换为整数。
 func (e floatElement) Int() (int, error) {
 	if e.IsNA() {
-		return 0, fmt.Errorf("无法将 NaN 转换为整数")
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
 	}
 	f := e.e
 	if math.IsInf(f, 1) || math.IsInf(f, -1) {
-		return 0, fmt.Errorf("无法将 Inf 转换为整数")
+		return 0, NewError(ErrConversion, "", "convert_inf", "int")
 	}
 	if math.IsNaN(f) {
-		return 0, fmt.Errorf("无法将 NaN 转换为整数")
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
 	}
 	return int(f), nil
 }
@@ -112,7 +112,7 @@ func (e floatElement) Float() float64 {
 // Bool 将元素转换为布尔值。
 func (e floatElement) Bool() (bool, error) {
 	if e.IsNA() {
-		return false, fmt.Errorf("无法将 NaN 转换为布尔值")
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
 	}
 	switch e.e {
 	case 1:
@@ -120,7 +120,7 @@ func (e floatElement) Bool() (bool, error) {
 	case 0:
 		return false, nil
 	}
-	return false, fmt.Errorf("无法将浮点数 \"%v\" 转换为布尔值", e.e)
+	return false, NewError(ErrConversion, "", "convert_value", Float, fmt.Sprint(e.e), "bool")
 }
 
 // Eq 比较两个元素是否相等。
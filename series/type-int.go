@@ -99,7 +99,7 @@ func (e intElement) String() string {
 // Int 方法返回整数元素的整数值。
 func (e intElement) Int() (int, error) {
 	if e.IsNA() {
-		return 0, fmt.Errorf("can't convert NaN to int")
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
 	}
 	return int(e.e), nil
 }
@@ -115,7 +115,7 @@ func (e intElement) Float() float64 {
 // Bool 方法将整数元素转换为布尔值。
 func (e intElement) Bool() (bool, error) {
 	if e.IsNA() {
-		return false, fmt.Errorf("can't convert NaN to bool")
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
 	}
 	switch e.e {
 	case 1:
@@ -123,7 +123,7 @@ func (e intElement) Bool() (bool, error) {
 	case 0:
 		return false, nil
 	}
-	return false, fmt.Errorf("can't convert Int \"%v\" to bool", e.e)
+	return false, NewError(ErrConversion, "", "convert_value", Int, fmt.Sprint(e.e), "bool")
 }
 
 // Eq 方法检查整数元素是否等于另一个元素。
@@ -0,0 +1,435 @@
+package series
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// intKind 描述固定位数的整数类型。
+type intKind struct {
+	t      Type
+	bits   int
+	signed bool
+}
+
+// intKinds 是所有固定位数的整数类型。
+var intKinds = map[Type]*intKind{
+	Int8:   {Int8, 8, true},
+	Int16:  {Int16, 16, true},
+	Int32:  {Int32, 32, true},
+	Int64:  {Int64, 64, true},
+	Uint8:  {Uint8, 8, false},
+	Uint16: {Uint16, 16, false},
+	Uint32: {Uint32, 32, false},
+	Uint64: {Uint64, 64, false},
+}
+
+// IsInteger 检查 t 是否为 Int 或固定位数的整数类型 Int8 至 Int64、Uint8 至 Uint64。
+func IsInteger(t Type) bool {
+	_, ok := intKinds[t]
+	return ok || t == Int
+}
+
+// IsNumeric 检查 t 是否为数值类型，即整数类型、Float 或 Decimal。
+func IsNumeric(t Type) bool {
+	return IsInteger(t) || t == Float || IsDecimal(t)
+}
+
+// fits 检查符号为 neg、绝对值为 mag 的整数是否在类型的范围内。
+func (k *intKind) fits(neg bool, mag uint64) bool {
+	if !k.signed {
+		return (!neg || mag == 0) && (k.bits == 64 || mag <= 1<<k.bits-1)
+	}
+	if neg {
+		return mag <= 1<<(k.bits-1)
+	}
+	return mag <= 1<<(k.bits-1)-1
+}
+
+// fitsBig 检查整数 v 是否在类型的范围内。
+func (k *intKind) fitsBig(v *big.Int) bool {
+	if !v.IsUint64() && !v.IsInt64() {
+		return false
+	}
+	mag := new(big.Int).Abs(v)
+	return mag.IsUint64() && k.fits(v.Sign() < 0, mag.Uint64())
+}
+
+// sizedIntElements 是固定位数的整数类型元素的具体实现。
+type sizedIntElements []sizedIntElement
+
+func (e sizedIntElements) Len() int           { return len(e) }
+func (e sizedIntElements) Elem(i int) Element { return &e[i] }
+
+// newSizedIntElements 创建 n 个类型为 kind 的元素。
+func newSizedIntElements(n int, kind *intKind) sizedIntElements {
+	elements := make(sizedIntElements, n)
+	for i := range elements {
+		elements[i].kind = kind
+	}
+	return elements
+}
+
+// conversionError 返回第一个因超出范围或有小数部分而被标记为 NaN 的元素的错误，没有时返回 nil。
+func (e sizedIntElements) conversionError(op string) error {
+	for i := range e {
+		if err := e[i].conversionError(op, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sizedIntElement 表示 Series 中固定位数的整数元素。有符号类型的值为 int64(v)，无符号类型的值为 v。
+type sizedIntElement struct {
+	v        uint64
+	nan      bool
+	overflow bool // 最近一次设置的值超出了类型的范围
+	fraction bool // 最近一次设置的值是有小数部分的浮点数
+	kind     *intKind
+}
+
+// 强制 sizedIntElement 结构实现 Element 接口。
+var _ Element = (*sizedIntElement)(nil)
+
+// Set 方法将给定的值设置为整数元素。超出类型范围的值和有小数部分的浮点数标记为 NaN，
+// 并被 New、Append 和 Series.Set 报告为错误；无法解析的字符串和 "NaN" 标记为 NaN。
+func (e *sizedIntElement) Set(value interface{}) {
+	e.nan, e.overflow, e.fraction = false, false, false
+	neg, mag, ok := intValue(value)
+	if !ok {
+		e.nan, e.fraction = true, fractional(value)
+		return
+	}
+	if !e.kind.fits(neg, mag) {
+		e.nan, e.overflow = true, true
+		return
+	}
+	e.v = mag
+	if neg {
+		e.v = -mag
+	}
+}
+
+// conversionError 返回元素最近一次设置值时的转换错误，i 为元素的位置，没有错误时返回 nil。
+func (e *sizedIntElement) conversionError(op string, i int) error {
+	switch {
+	case e.overflow:
+		return NewError(ErrConversion, op, "int_range", i, e.kind.t)
+	case e.fraction:
+		return NewError(ErrConversion, op, "int_fraction", i, e.kind.t)
+	}
+	return nil
+}
+
+// fractional 检查值是否为有小数部分的有限浮点数，包括 Float 和 Decimal 类型的元素。
+func fractional(value interface{}) bool {
+	var f float64
+	switch val := value.(type) {
+	case float64:
+		f = val
+	case float32:
+		f = float64(val)
+	case Element:
+		if val.IsNA() || (val.Type() != Float && val.Type() != Decimal) {
+			return false
+		}
+		f = val.Float()
+	default:
+		return false
+	}
+	return !math.IsNaN(f) && !math.IsInf(f, 0) && f != math.Trunc(f)
+}
+
+// intValue 将值转换为符号和绝对值，无法转换时返回 false。绝对值超过 uint64 范围的值返回 math.MaxUint64，
+// 由调用方按照超出范围处理。
+func intValue(value interface{}) (neg bool, mag uint64, ok bool) {
+	switch val := value.(type) {
+	case string:
+		return parseIntValue(val)
+	case int:
+		return signMag(int64(val))
+	case int8:
+		return signMag(int64(val))
+	case int16:
+		return signMag(int64(val))
+	case int32:
+		return signMag(int64(val))
+	case int64:
+		return signMag(val)
+	case uint:
+		return false, uint64(val), true
+	case uint8:
+		return false, uint64(val), true
+	case uint16:
+		return false, uint64(val), true
+	case uint32:
+		return false, uint64(val), true
+	case uint64:
+		return false, val, true
+	case float32:
+		return intValue(float64(val))
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) || val != math.Trunc(val) {
+			return false, 0, false
+		}
+		if math.Abs(val) >= 1<<64 {
+			return val < 0, math.MaxUint64, true
+		}
+		return val < 0, uint64(math.Abs(val)), true
+	case bool:
+		if val {
+			return false, 1, true
+		}
+		return false, 0, true
+	case *sizedIntElement:
+		if val.IsNA() {
+			return false, 0, false
+		}
+		return val.signMag()
+	case Element:
+		if val.IsNA() {
+			return false, 0, false
+		}
+		switch val.Type() {
+		case Float:
+			return intValue(val.Float())
+		case Int, Bool:
+			i, err := val.Int()
+			if err != nil {
+				return false, 0, false
+			}
+			return signMag(int64(i))
+		}
+		if neg, mag, ok := parseIntValue(val.String()); ok {
+			return neg, mag, true
+		}
+		i, err := val.Int()
+		if err != nil {
+			return false, 0, false
+		}
+		return signMag(int64(i))
+	}
+	return false, 0, false
+}
+
+// parseIntValue 解析十进制整数字符串，绝对值超过 uint64 范围时返回 math.MaxUint64。
+func parseIntValue(s string) (neg bool, mag uint64, ok bool) {
+	digits := strings.TrimPrefix(s, "+")
+	if strings.HasPrefix(s, "-") {
+		neg, digits = true, s[1:]
+	}
+	if digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return false, 0, false
+	}
+	mag, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		if ne, isNum := err.(*strconv.NumError); isNum && ne.Err == strconv.ErrRange {
+			return neg, math.MaxUint64, true
+		}
+		return false, 0, false
+	}
+	return neg, mag, true
+}
+
+// signMag 返回整数的符号和绝对值。
+func signMag(i int64) (neg bool, mag uint64, ok bool) {
+	if i < 0 {
+		return true, -uint64(i), true
+	}
+	return false, uint64(i), true
+}
+
+// signMag 返回元素值的符号和绝对值。
+func (e sizedIntElement) signMag() (neg bool, mag uint64, ok bool) {
+	if e.kind.signed {
+		return signMag(int64(e.v))
+	}
+	return false, e.v, true
+}
+
+// big 返回元素的值。
+func (e sizedIntElement) big() *big.Int {
+	if e.kind.signed {
+		return big.NewInt(int64(e.v))
+	}
+	return new(big.Int).SetUint64(e.v)
+}
+
+// Copy 方法返回整数元素的副本。
+func (e sizedIntElement) Copy() Element {
+	return &sizedIntElement{v: e.v, nan: e.nan, kind: e.kind}
+}
+
+// IsNA 方法检查整数元素是否为 NaN。
+func (e sizedIntElement) IsNA() bool {
+	return e.nan || e.kind == nil
+}
+
+// Type 方法返回整数元素的类型。
+func (e sizedIntElement) Type() Type {
+	return e.kind.t
+}
+
+// Val 方法返回与类型对应的 Go 整数值，例如 Int8 返回 int8，Uint64 返回 uint64。
+func (e sizedIntElement) Val() ElementValue {
+	if e.IsNA() {
+		return nil
+	}
+	switch e.kind.t {
+	case Int8:
+		return int8(e.v)
+	case Int16:
+		return int16(e.v)
+	case Int32:
+		return int32(e.v)
+	case Int64:
+		return int64(e.v)
+	case Uint8:
+		return uint8(e.v)
+	case Uint16:
+		return uint16(e.v)
+	case Uint32:
+		return uint32(e.v)
+	}
+	return e.v
+}
+
+// String 方法返回整数元素的十进制表示。
+func (e sizedIntElement) String() string {
+	if e.IsNA() {
+		return "NaN"
+	}
+	if e.kind.signed {
+		return strconv.FormatInt(int64(e.v), 10)
+	}
+	return strconv.FormatUint(e.v, 10)
+}
+
+// Int 方法将整数元素转换为 int，超出 int 范围时返回错误。
+func (e sizedIntElement) Int() (int, error) {
+	if e.IsNA() {
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
+	}
+	neg, mag, _ := e.signMag()
+	if !intKinds[Int64].fits(neg, mag) || int64(int(e.v)) != int64(e.v) {
+		return 0, NewError(ErrConversion, "", "convert_value", e.kind.t, e.String(), "int")
+	}
+	return int(e.v), nil
+}
+
+// Float 方法将整数元素转换为浮点数，超过 2^53 的值可能损失精度。
+func (e sizedIntElement) Float() float64 {
+	if e.IsNA() {
+		return math.NaN()
+	}
+	if e.kind.signed {
+		return float64(int64(e.v))
+	}
+	return float64(e.v)
+}
+
+// Bool 方法将整数元素转换为布尔值，只有 1 和 0 可以转换。
+func (e sizedIntElement) Bool() (bool, error) {
+	if e.IsNA() {
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
+	}
+	switch e.v {
+	case 1:
+		return true, nil
+	case 0:
+		return false, nil
+	}
+	return false, NewError(ErrConversion, "", "convert_value", e.kind.t, e.String(), "bool")
+}
+
+// compare 比较整数元素与另一个元素，返回 -1、0 或 1。整数之间精确比较，其他元素按浮点数比较。
+// 任意一方为 NaN 时，ok 为 false。
+func (e sizedIntElement) compare(elem Element) (cmp int, ok bool) {
+	if e.IsNA() || elem.IsNA() {
+		return 0, false
+	}
+	neg, mag, _ := e.signMag()
+	switch elem.Type() {
+	case Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32, Uint64, Int, Bool:
+		oneg, omag, ok := intValue(elem)
+		if !ok {
+			return 0, false
+		}
+		return compareSignMag(neg, mag, oneg, omag), true
+	}
+	f := elem.Float()
+	if math.IsNaN(f) {
+		return 0, false
+	}
+	x := e.Float()
+	switch {
+	case x < f:
+		return -1, true
+	case x > f:
+		return 1, true
+	}
+	return 0, true
+}
+
+// compareSignMag 比较两个以符号和绝对值表示的整数，返回 -1、0 或 1。
+func compareSignMag(aneg bool, amag uint64, bneg bool, bmag uint64) int {
+	if amag == 0 && bmag == 0 {
+		return 0
+	}
+	if aneg != bneg {
+		if aneg {
+			return -1
+		}
+		return 1
+	}
+	cmp := 0
+	switch {
+	case amag < bmag:
+		cmp = -1
+	case amag > bmag:
+		cmp = 1
+	}
+	if aneg {
+		return -cmp
+	}
+	return cmp
+}
+
+// Eq 方法检查整数元素是否等于另一个元素。
+func (e sizedIntElement) Eq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp == 0
+}
+
+// Neq 方法检查整数元素是否不等于另一个元素。
+func (e sizedIntElement) Neq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp != 0
+}
+
+// Less 方法检查整数元素是否小于另一个元素。
+func (e sizedIntElement) Less(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp < 0
+}
+
+// LessEq 方法检查整数元素是否小于或等于另一个元素。
+func (e sizedIntElement) LessEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp <= 0
+}
+
+// Greater 方法检查整数元素是否大于另一个元素。
+func (e sizedIntElement) Greater(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp > 0
+}
+
+// GreaterEq 方法检查整数元素是否大于或等于另一个元素。
+func (e sizedIntElement) GreaterEq(elem Element) bool {
+	cmp, ok := e.compare(elem)
+	return ok && cmp >= 0
+}
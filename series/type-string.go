@@ -1,7 +1,6 @@
 package series
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -39,6 +38,10 @@ func (e *stringElement) Set(value interface{}) {
 			e.e = "false"
 		}
 	case Element:
+		if val.IsNA() {
+			e.nan = true
+			return
+		}
 		e.e = val.String()
 	default:
 		e.nan = true
@@ -83,9 +86,13 @@ func (e stringElement) String() string {
 // Int 方法将字符串元素转换为整数。
 func (e stringElement) Int() (int, error) {
 	if e.IsNA() {
-		return 0, fmt.Errorf("can't convert NaN to int")
+		return 0, NewError(ErrConversion, "", "convert_nan", "int")
+	}
+	i, err := strconv.Atoi(e.e)
+	if err != nil {
+		return 0, NewError(ErrConversion, "", "convert_value", String, e.e, "int").Wrap(err)
 	}
-	return strconv.Atoi(e.e)
+	return i, nil
 }
 
 // Float 方法将字符串元素转换为浮点数。
@@ -103,7 +110,7 @@ func (e stringElement) Float() float64 {
 // Bool 方法将字符串元素转换为布尔值。
 func (e stringElement) Bool() (bool, error) {
 	if e.IsNA() {
-		return false, fmt.Errorf("can't convert NaN to bool")
+		return false, NewError(ErrConversion, "", "convert_nan", "bool")
 	}
 	switch strings.ToLower(e.e) {
 	case "true", "t", "1":
@@ -111,7 +118,7 @@ func (e stringElement) Bool() (bool, error) {
 	case "false", "f", "0":
 		return false, nil
 	}
-	return false, fmt.Errorf("can't convert String \"%v\" to bool", e.e)
+	return false, NewError(ErrConversion, "", "convert_value", String, e.e, "bool")
 }
 
 // Eq 方法检查字符串元素是否等于另一个元素。